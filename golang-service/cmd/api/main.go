@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
 	"github.com/Nezent/Saracen_Voting_System/internal/infrastructure/database"
 	httpHandler "github.com/Nezent/Saracen_Voting_System/internal/interfaces/http"
 	"github.com/gorilla/mux"
@@ -40,21 +46,61 @@ func main() {
 
 	// Initialize repositories
 	voterRepo := database.NewPostgresVoterRepository(db)
+	voterKeyRepo := database.NewPostgresVoterKeyRepository(db)
 	voteRepo := database.NewPostgresVoteRepository(db)
 	encryptedBallotRepo := database.NewEncryptedBallotRepository(db)
+	electionKeyRepo := database.NewElectionKeyRepository(db)
+	trusteeRepo := database.NewPostgresTrusteeRepository(db)
+	boardRepo := database.NewPostgresBoardRepository(db)
+	receiptRepo := database.NewPostgresReceiptRepository(db)
 	rankedBallotRepo := database.NewRankedBallotRepository(db)
+	cardinalBallotRepo := database.NewCardinalBallotRepository(db)
+	electionRepo := database.NewPostgresElectionRepository(db)
+	candidateRepo := database.NewPostgresCandidateRepository(db)
+
+	// Register the counting methods elections can be tabulated with
+	tabulators := ballot.DefaultTabulators()
+
+	// Ballots are stored under random IDs that reveal nothing about when they were cast
+	ballotIDs := ballot.NewRandomIDGenerator()
 
 	// Initialize services
-	voterService := application.NewVoterService(voterRepo)
-	voteService := application.NewVoteService(voteRepo, voterRepo)
-	encryptedBallotService := application.NewEncryptedBallotService(encryptedBallotRepo, voterRepo)
-	rankedBallotService := application.NewRankedBallotService(rankedBallotRepo, voterRepo)
+	voterService := application.NewVoterService(voterRepo, electionRepo)
+	voterKeyService := application.NewVoterKeyService(voterKeyRepo, voterRepo, electionRepo)
+	voteService := application.NewVoteService(voteRepo, voterRepo, candidateRepo)
+	candidateService := application.NewCandidateService(candidateRepo)
+	electionService := application.NewElectionService(electionRepo, candidateRepo, tabulators)
+	encryptedBallotService := application.NewEncryptedBallotService(encryptedBallotRepo, voterRepo, voterKeyRepo, electionRepo, electionKeyRepo, ballotIDs)
+	rankedBallotService := application.NewRankedBallotService(rankedBallotRepo, voterRepo, electionRepo, candidateRepo, ballotIDs)
+	cardinalBallotService := application.NewCardinalBallotService(cardinalBallotRepo, voterRepo, electionRepo, candidateRepo, ballotIDs)
+	resultsService := application.NewResultsService(electionRepo, rankedBallotRepo, cardinalBallotRepo, tabulators)
+	encryptedTallyService := application.NewEncryptedTallyService(electionRepo, encryptedBallotRepo, electionKeyRepo, trusteeRepo)
+	trusteeService := application.NewTrusteeService(trusteeRepo, electionRepo, encryptedBallotRepo, electionKeyRepo)
+	boardService := application.NewBoardService(boardRepo, electionRepo, loadBoardSigningKey())
+	receiptService := application.NewReceiptService(receiptRepo)
 
 	// Initialize handlers
 	voterHandler := httpHandler.NewVoterHandler(voterService)
+	voterKeyHandler := httpHandler.NewVoterKeyHandler(voterKeyService)
 	voteHandler := httpHandler.NewVoteHandler(voteService)
 	encryptedBallotHandler := httpHandler.NewEncryptedBallotHandler(encryptedBallotService)
 	rankedBallotHandler := httpHandler.NewRankedBallotHandler(rankedBallotService)
+	cardinalBallotHandler := httpHandler.NewCardinalBallotHandler(cardinalBallotService)
+	electionHandler := httpHandler.NewElectionHandler(electionService)
+	resultsHandler := httpHandler.NewResultsHandler(resultsService)
+	encryptedTallyHandler := httpHandler.NewEncryptedTallyHandler(encryptedTallyService)
+	trusteeHandler := httpHandler.NewTrusteeHandler(trusteeService)
+	boardHandler := httpHandler.NewBoardHandler(boardService)
+	receiptHandler := httpHandler.NewReceiptHandler(receiptService)
+	candidateHandler := httpHandler.NewCandidateHandler(candidateService)
+
+	// Start the election scheduler that opens and closes elections on time
+	electionScheduler := application.NewElectionScheduler(electionRepo, 30*time.Second)
+	go electionScheduler.Run(context.Background())
+
+	// Start the board checkpointer that signs the bulletin boards of encrypted ballots as they grow
+	boardCheckpointer := application.NewBoardCheckpointer(boardService, time.Minute)
+	go boardCheckpointer.Run(context.Background())
 
 	// Setup routes
 	router := mux.NewRouter()
@@ -66,6 +112,54 @@ func main() {
 	router.HandleFunc("/api/voters/{voter_id:[0-9]+}", voterHandler.UpdateVoter).Methods("PUT")
 	router.HandleFunc("/api/voters/{voter_id:[0-9]+}", voterHandler.DeleteVoter).Methods("DELETE")
 
+	// Voter key routes (keys that sign encrypted ballots)
+	router.HandleFunc("/api/voters/{voter_id:[0-9]+}/keys", voterKeyHandler.EnrollKey).Methods("POST")
+	router.HandleFunc("/api/voters/{voter_id:[0-9]+}/keys", voterKeyHandler.GetVoterKeys).Methods("GET")
+	router.HandleFunc("/api/voters/{voter_id:[0-9]+}/keys/{key_id:[0-9]+}", voterKeyHandler.RevokeKey).Methods("DELETE")
+
+	// Candidate routes
+	router.HandleFunc("/api/candidates", candidateHandler.CreateCandidate).Methods("POST")
+	router.HandleFunc("/api/candidates/{candidate_id:[0-9]+}", candidateHandler.GetCandidate).Methods("GET")
+	router.HandleFunc("/api/candidates", candidateHandler.GetAllCandidates).Methods("GET")
+	router.HandleFunc("/api/candidates/{candidate_id:[0-9]+}", candidateHandler.UpdateCandidate).Methods("PUT")
+	router.HandleFunc("/api/candidates/{candidate_id:[0-9]+}", candidateHandler.DeleteCandidate).Methods("DELETE")
+
+	// Election routes
+	router.HandleFunc("/api/elections", electionHandler.CreateElection).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}", electionHandler.GetElection).Methods("GET")
+	router.HandleFunc("/api/elections", electionHandler.GetAllElections).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}", electionHandler.UpdateElection).Methods("PUT")
+	router.HandleFunc("/api/elections/{election_id}", electionHandler.DeleteElection).Methods("DELETE")
+	router.HandleFunc("/api/elections/{election_id}/transitions", electionHandler.TransitionElection).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/transitions", electionHandler.GetElectionTransitions).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/results", resultsHandler.GetElectionResults).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/key", encryptedTallyHandler.CreateElectionKey).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/key", encryptedTallyHandler.GetElectionKey).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/encrypted-tally", encryptedTallyHandler.GetEncryptedTally).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/encrypted-tally", encryptedTallyHandler.DecryptEncryptedTally).Methods("POST")
+
+	// Trustee key generation and threshold decryption routes
+	router.HandleFunc("/api/elections/{election_id}/trustees", trusteeHandler.CreateCeremony).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/trustees", trusteeHandler.GetCeremony).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/trustees/key", trusteeHandler.FinalizeCeremony).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/trustees/{index:[0-9]+}/commitments", trusteeHandler.SubmitCommitments).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/trustees/{index:[0-9]+}/complaints", trusteeHandler.FileComplaint).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/trustees/{index:[0-9]+}/revealed-shares", trusteeHandler.RevealShare).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/trustees/{index:[0-9]+}/decryption-shares", trusteeHandler.SubmitDecryptionShare).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/encrypted-aggregate", trusteeHandler.GetAggregate).Methods("GET")
+
+	// Bulletin board routes
+	router.HandleFunc("/api/elections/{election_id}/board/entries", boardHandler.GetEntries).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/board/checkpoints", boardHandler.GetCheckpoints).Methods("GET")
+	router.HandleFunc("/api/elections/{election_id}/board/checkpoints", boardHandler.CreateCheckpoint).Methods("POST")
+	router.HandleFunc("/api/elections/{election_id}/board/consistency", boardHandler.GetConsistencyProof).Methods("GET")
+
+	// Receipt routes
+	router.HandleFunc("/api/receipts/{code}", receiptHandler.GetReceipt).Methods("GET")
+
+	// Counting method routes
+	router.HandleFunc("/api/tabulators", resultsHandler.GetTabulators).Methods("GET")
+
 	// Vote routes (Q13, Q14, Q15)
 	router.HandleFunc("/api/votes/timeline", voteHandler.GetVoteTimeline).Methods("GET")
 	router.HandleFunc("/api/votes/weighted", voteHandler.CastWeightedVote).Methods("POST")
@@ -78,10 +172,24 @@ func main() {
 
 	// Ranked Ballot routes (Q19)
 	router.HandleFunc("/api/ballots/ranked", rankedBallotHandler.CreateRankedBallot).Methods("POST")
+	router.HandleFunc("/api/ballots/ranked/results", resultsHandler.GetBallotResults).Methods("GET")
 	router.HandleFunc("/api/ballots/ranked/{ballot_id}", rankedBallotHandler.GetRankedBallot).Methods("GET")
 	router.HandleFunc("/api/ballots/ranked", rankedBallotHandler.GetRankedBallotsByElection).Methods("GET")
-	router.HandleFunc("/api/ballots/ranked/results", rankedBallotHandler.GetSchulzeResults).Methods("GET")
-	router.HandleFunc("/api/ballots/ranked/voter/{voter_id:[0-9]+}", rankedBallotHandler.GetVoterBallots).Methods("GET")
+	router.HandleFunc("/api/ballots/ranked/voter/{voter_id:[0-9]+}", rankedBallotHandler.GetVoterParticipation).Methods("GET")
+
+	// Approval Ballot routes
+	router.HandleFunc("/api/ballots/approval", cardinalBallotHandler.CreateApprovalBallot).Methods("POST")
+	router.HandleFunc("/api/ballots/approval/results", resultsHandler.GetBallotResults).Methods("GET")
+	router.HandleFunc("/api/ballots/approval/{ballot_id}", cardinalBallotHandler.GetCardinalBallot).Methods("GET")
+	router.HandleFunc("/api/ballots/approval", cardinalBallotHandler.GetApprovalBallotsByElection).Methods("GET")
+	router.HandleFunc("/api/ballots/approval/voter/{voter_id:[0-9]+}", cardinalBallotHandler.GetApprovalVoterBallots).Methods("GET")
+
+	// Score Ballot routes (score and STAR tabulation)
+	router.HandleFunc("/api/ballots/score", cardinalBallotHandler.CreateScoreBallot).Methods("POST")
+	router.HandleFunc("/api/ballots/score/results", resultsHandler.GetBallotResults).Methods("GET")
+	router.HandleFunc("/api/ballots/score/{ballot_id}", cardinalBallotHandler.GetCardinalBallot).Methods("GET")
+	router.HandleFunc("/api/ballots/score", cardinalBallotHandler.GetScoreBallotsByElection).Methods("GET")
+	router.HandleFunc("/api/ballots/score/voter/{voter_id:[0-9]+}", cardinalBallotHandler.GetScoreVoterBallots).Methods("GET")
 
 	// Health check endpoint
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -98,3 +206,23 @@ func main() {
 	log.Printf("Server starting on port %s...", port)
 	log.Fatal(http.ListenAndServe(":"+port, router))
 }
+
+// loadBoardSigningKey reads the Ed25519 key that signs board checkpoints from BOARD_SIGNING_KEY,
+// a hex 32-byte seed. Without one, checkpoints are signed with a key generated for this run.
+func loadBoardSigningKey() ed25519.PrivateKey {
+	seedHex := os.Getenv("BOARD_SIGNING_KEY")
+	if seedHex == "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			log.Fatal("Failed to generate board signing key:", err)
+		}
+		log.Printf("Warning: BOARD_SIGNING_KEY is not set, signing board checkpoints with temporary key %s", hex.EncodeToString(key.Public().(ed25519.PublicKey)))
+		return key
+	}
+
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		log.Fatalf("BOARD_SIGNING_KEY must be a hex %d-byte Ed25519 seed", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed)
+}
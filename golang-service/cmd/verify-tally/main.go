@@ -0,0 +1,103 @@
+// Command verify-tally rebuilds the pairwise tallies of ranked elections from their
+// ballots and reports any drift from the tallies stored as ballots were cast.
+//
+// Usage:
+//
+//	verify-tally [-election <election_id>]
+//
+// Without -election every ranked election is checked. The exit status is 1 if any
+// tally has drifted and 2 if a tally could not be checked.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/infrastructure/database"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	electionID := flag.String("election", "", "verify a single election instead of every ranked election")
+	flag.Parse()
+
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Error loading .env file: %v", err)
+	}
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL environment variable is required")
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		log.Fatal("Failed to ping database:", err)
+	}
+
+	electionRepo := database.NewPostgresElectionRepository(db)
+	rankedBallotService := application.NewRankedBallotService(
+		database.NewRankedBallotRepository(db),
+		database.NewPostgresVoterRepository(db),
+		electionRepo,
+		database.NewPostgresCandidateRepository(db),
+		ballot.NewRandomIDGenerator(),
+	)
+
+	electionIDs := []string{*electionID}
+	if *electionID == "" {
+		elections, err := electionRepo.GetAll()
+		if err != nil {
+			log.Fatal("Failed to list elections:", err)
+		}
+
+		electionIDs = electionIDs[:0]
+		for _, e := range elections {
+			if e.BallotType == election.BallotTypeRanked {
+				electionIDs = append(electionIDs, e.ElectionID)
+			}
+		}
+	}
+
+	exitCode := 0
+	for _, id := range electionIDs {
+		verification, err := rankedBallotService.VerifyPairwiseTally(id)
+		if err != nil {
+			fmt.Printf("%s: ERROR %v\n", id, err)
+			exitCode = 2
+			continue
+		}
+
+		if verification.Consistent {
+			fmt.Printf("%s: OK (%d ballots)\n", id, verification.Ballots)
+			continue
+		}
+
+		fmt.Printf("%s: DRIFT (%d ballots, %d counts differ)\n", id, verification.Ballots, len(verification.Drift))
+		for _, drift := range verification.Drift {
+			if drift.CandidateB == 0 {
+				fmt.Printf("  candidate %d %s: stored %d, rebuilt %d\n", drift.CandidateA, drift.Count, drift.Stored, drift.Rebuilt)
+			} else {
+				fmt.Printf("  pair %d/%d %s: stored %d, rebuilt %d\n", drift.CandidateA, drift.CandidateB, drift.Count, drift.Stored, drift.Rebuilt)
+			}
+		}
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	}
+
+	os.Exit(exitCode)
+}
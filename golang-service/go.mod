@@ -3,6 +3,7 @@ module github.com/Nezent/Saracen_Voting_System
 go 1.23.4
 
 require (
+	filippo.io/edwards25519 v1.1.0
 	github.com/gorilla/mux v1.8.1
 	github.com/lib/pq v1.10.9
 )
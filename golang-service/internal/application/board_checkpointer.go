@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// BoardCheckpointer signs checkpoints of bulletin boards that grew since their last checkpoint
+type BoardCheckpointer struct {
+	service  *BoardService
+	interval time.Duration
+}
+
+// NewBoardCheckpointer creates a new board checkpointer that checks boards every interval
+func NewBoardCheckpointer(service *BoardService, interval time.Duration) *BoardCheckpointer {
+	return &BoardCheckpointer{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run checkpoints boards until the context is cancelled
+func (c *BoardCheckpointer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.service.CheckpointAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/board"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+)
+
+// BoardService publishes the bulletin board of accepted encrypted ballots and signs its checkpoints
+type BoardService struct {
+	boardRepo    board.Repository
+	electionRepo election.Repository
+	signingKey   ed25519.PrivateKey
+}
+
+// NewBoardService creates a new bulletin board service that signs checkpoints with the given key
+func NewBoardService(boardRepo board.Repository, electionRepo election.Repository, signingKey ed25519.PrivateKey) *BoardService {
+	return &BoardService{
+		boardRepo:    boardRepo,
+		electionRepo: electionRepo,
+		signingKey:   signingKey,
+	}
+}
+
+// GetEntries retrieves up to limit board entries of an election with a sequence number above after
+func (s *BoardService) GetEntries(electionID string, after int64, limit int) (*board.EntriesResponse, error) {
+	if err := s.checkElection(electionID); err != nil {
+		return nil, err
+	}
+
+	if after < 0 {
+		return nil, fmt.Errorf("after must be 0 or more")
+	}
+	if limit == 0 {
+		limit = board.DefaultEntryLimit
+	}
+	if limit < 1 || limit > board.MaxEntryLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", board.MaxEntryLimit)
+	}
+
+	entries, err := s.boardRepo.GetEntries(electionID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &board.EntriesResponse{ElectionID: electionID, Entries: entries}, nil
+}
+
+// GetCheckpoints retrieves every signed checkpoint of an election's board
+func (s *BoardService) GetCheckpoints(electionID string) (*board.CheckpointsResponse, error) {
+	if err := s.checkElection(electionID); err != nil {
+		return nil, err
+	}
+
+	checkpoints, err := s.boardRepo.GetCheckpoints(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &board.CheckpointsResponse{ElectionID: electionID, Checkpoints: checkpoints}, nil
+}
+
+// CreateCheckpoint signs the current size and head hash of an election's board
+func (s *BoardService) CreateCheckpoint(electionID string) (*board.Checkpoint, error) {
+	if err := s.checkElection(electionID); err != nil {
+		return nil, err
+	}
+
+	head, err := s.boardRepo.GetHead(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, fmt.Errorf("validation failed: board of election %s has no entries", electionID)
+	}
+
+	checkpoint, err := board.NewCheckpoint(electionID, head.Sequence, head.Hash, s.signingKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.boardRepo.CreateCheckpoint(checkpoint); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// CheckpointAll signs a checkpoint of every board with entries after its latest checkpoint
+func (s *BoardService) CheckpointAll() {
+	electionIDs, err := s.boardRepo.GetUncheckpointedElections()
+	if err != nil {
+		log.Printf("Warning: board checkpointer failed to load boards: %v", err)
+		return
+	}
+
+	for _, electionID := range electionIDs {
+		if _, err := s.CreateCheckpoint(electionID); err != nil {
+			log.Printf("Warning: board checkpointer could not checkpoint election %s: %v", electionID, err)
+		}
+	}
+}
+
+// GetConsistencyProof proves that the board at checkpoint to extends the board at checkpoint from.
+// A from of 0 is the empty board. The proof is checked against the stored checkpoints before it is
+// returned, so rows removed or rewritten since a checkpoint are reported instead of proven.
+func (s *BoardService) GetConsistencyProof(electionID string, from, to int64) (*board.ConsistencyProof, error) {
+	if err := s.checkElection(electionID); err != nil {
+		return nil, err
+	}
+
+	if from < 0 || to <= from {
+		return nil, fmt.Errorf("invalid range: from must be at least 0 and below to")
+	}
+	if to-from > board.MaxEntryLimit {
+		return nil, fmt.Errorf("invalid range: a proof covers at most %d entries", board.MaxEntryLimit)
+	}
+
+	fromHash := hex.EncodeToString(board.GenesisHash(electionID))
+	if from > 0 {
+		fromCheckpoint, err := s.boardRepo.GetCheckpoint(electionID, from)
+		if err != nil {
+			return nil, err
+		}
+		fromHash = fromCheckpoint.HeadHash
+	}
+	toCheckpoint, err := s.boardRepo.GetCheckpoint(electionID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.boardRepo.GetEntries(electionID, from, int(to-from))
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := board.NewConsistencyProof(electionID, from, to, fromHash, entries)
+	if err != nil {
+		return nil, err
+	}
+	if err := proof.Verify(); err != nil || proof.ToHash != toCheckpoint.HeadHash {
+		return nil, fmt.Errorf("board of election %s is inconsistent with its checkpoints at sizes %d and %d", electionID, from, to)
+	}
+
+	return proof, nil
+}
+
+// checkElection returns an error unless the election exists
+func (s *BoardService) checkElection(electionID string) error {
+	if electionID == "" {
+		return fmt.Errorf("election_id is required")
+	}
+
+	_, err := s.electionRepo.GetByID(electionID)
+	return err
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+)
+
+// CandidateService implements the candidate.Service interface
+type CandidateService struct {
+	repo candidate.Repository
+}
+
+// NewCandidateService creates a new candidate service
+func NewCandidateService(repo candidate.Repository) candidate.Service {
+	return &CandidateService{repo: repo}
+}
+
+// CreateCandidate creates a new candidate with validation
+func (s *CandidateService) CreateCandidate(req candidate.CandidateRequest) (*candidate.Candidate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	c := &candidate.Candidate{
+		Name:  req.Name,
+		Party: req.Party,
+	}
+
+	if err := s.repo.Create(c); err != nil {
+		return nil, fmt.Errorf("failed to create candidate: %w", err)
+	}
+
+	return c, nil
+}
+
+// GetCandidate retrieves a candidate by ID
+func (s *CandidateService) GetCandidate(candidateID int) (*candidate.Candidate, error) {
+	return s.repo.GetByID(candidateID)
+}
+
+// GetAllCandidates retrieves all candidates, optionally filtered by party
+func (s *CandidateService) GetAllCandidates(party string) (*candidate.CandidatesListResponse, error) {
+	candidates, err := s.repo.GetAll(party)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidates == nil {
+		candidates = []*candidate.Candidate{}
+	}
+
+	return &candidate.CandidatesListResponse{
+		Candidates: candidates,
+	}, nil
+}
+
+// UpdateCandidate updates an existing candidate's name and party
+func (s *CandidateService) UpdateCandidate(candidateID int, req candidate.CandidateRequest) (*candidate.Candidate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	c := &candidate.Candidate{
+		CandidateID: candidateID,
+		Name:        req.Name,
+		Party:       req.Party,
+	}
+
+	if err := s.repo.Update(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// DeleteCandidate deletes a candidate by ID
+func (s *CandidateService) DeleteCandidate(candidateID int) error {
+	return s.repo.Delete(candidateID)
+}
+
+// validateCandidatesExist returns an error naming the first candidate ID that does not exist
+func validateCandidatesExist(candidateRepo candidate.Repository, candidateIDs []int) error {
+	missing, err := candidateRepo.FindMissing(candidateIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("candidate with id: %d was not found", missing[0])
+	}
+
+	return nil
+}
+
+// validateBallotCandidates checks that every candidate exists and is on the election's slate
+func validateBallotCandidates(candidateRepo candidate.Repository, e *election.Election, candidateIDs []int) error {
+	if err := validateCandidatesExist(candidateRepo, candidateIDs); err != nil {
+		return err
+	}
+
+	for _, candidateID := range candidateIDs {
+		if !e.HasCandidate(candidateID) {
+			return fmt.Errorf("invalid candidate: candidate %d is not on the ballot for election %s", candidateID, e.ElectionID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+)
+
+// CardinalBallotService handles approval and score ballot business logic and tabulation
+type CardinalBallotService struct {
+	cardinalBallotRepo ballot.CardinalBallotRepository
+	voterRepo          voter.Repository
+	electionRepo       election.Repository
+	candidateRepo      candidate.Repository
+	ballotIDs          ballot.IDGenerator
+}
+
+// NewCardinalBallotService creates a new approval and score ballot service
+func NewCardinalBallotService(
+	cardinalBallotRepo ballot.CardinalBallotRepository,
+	voterRepo voter.Repository,
+	electionRepo election.Repository,
+	candidateRepo candidate.Repository,
+	ballotIDs ballot.IDGenerator,
+) *CardinalBallotService {
+	return &CardinalBallotService{
+		cardinalBallotRepo: cardinalBallotRepo,
+		voterRepo:          voterRepo,
+		electionRepo:       electionRepo,
+		candidateRepo:      candidateRepo,
+		ballotIDs:          ballotIDs,
+	}
+}
+
+// CreateApprovalBallot creates a new approval ballot
+func (s *CardinalBallotService) CreateApprovalBallot(req *ballot.ApprovalBallotRequest) (*ballot.CardinalBallotResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	electionEntity, err := s.checkBallotEligibility(req.VoterID, req.ElectionID, ballot.CardinalTypeApproval)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that every approved candidate exists and is on the ballot
+	if err := validateBallotCandidates(s.candidateRepo, electionEntity, req.Approved); err != nil {
+		return nil, err
+	}
+
+	// Convert request to domain model
+	cardinalBallot, scores, err := req.ToCardinalBallot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval ballot: %v", err)
+	}
+
+	return s.storeBallot(electionEntity, cardinalBallot, scores)
+}
+
+// CreateScoreBallot creates a new score ballot
+func (s *CardinalBallotService) CreateScoreBallot(req *ballot.ScoreBallotRequest) (*ballot.CardinalBallotResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	electionEntity, err := s.checkBallotEligibility(req.VoterID, req.ElectionID, ballot.CardinalTypeScore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scores are bounded by the election's scale
+	if err := req.ValidateMaxScore(electionEntity.MaxScore); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	// Validate that every scored candidate exists and is on the ballot
+	if err := validateBallotCandidates(s.candidateRepo, electionEntity, req.Candidates()); err != nil {
+		return nil, err
+	}
+
+	// Convert request to domain model
+	cardinalBallot, scores, err := req.ToCardinalBallot(electionEntity.MaxScore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create score ballot: %v", err)
+	}
+
+	return s.storeBallot(electionEntity, cardinalBallot, scores)
+}
+
+// GetCardinalBallot retrieves an approval or score ballot by ID with its scores
+func (s *CardinalBallotService) GetCardinalBallot(ballotID string) (*ballot.CardinalBallot, []ballot.BallotScore, error) {
+	if ballotID == "" {
+		return nil, nil, fmt.Errorf("ballot_id is required")
+	}
+
+	return s.cardinalBallotRepo.GetByBallotID(ballotID)
+}
+
+// GetCardinalBallotsByElection retrieves all ballots of a type for an election
+func (s *CardinalBallotService) GetCardinalBallotsByElection(electionID, ballotType string) ([]ballot.CardinalBallotWithScores, error) {
+	if electionID == "" {
+		return nil, fmt.Errorf("election_id is required")
+	}
+
+	return s.cardinalBallotRepo.GetByElectionID(electionID, ballotType)
+}
+
+// GetVoterBallots retrieves all ballots of a type cast by a voter
+func (s *CardinalBallotService) GetVoterBallots(voterID int, ballotType string) ([]*ballot.CardinalBallot, error) {
+	if voterID <= 0 {
+		return nil, fmt.Errorf("voter_id must be positive")
+	}
+
+	// Verify voter exists
+	_, err := s.voterRepo.GetByID(voterID)
+	if err != nil {
+		return nil, fmt.Errorf("voter not found: %v", err)
+	}
+
+	return s.cardinalBallotRepo.GetByVoterID(voterID, ballotType)
+}
+
+// checkBallotEligibility verifies that the voter exists, that the election is accepting ballots
+// of the given type, and that the voter has not voted yet unless the election allows revoting
+func (s *CardinalBallotService) checkBallotEligibility(voterID int, electionID, ballotType string) (*election.Election, error) {
+	// Verify voter exists
+	if _, err := s.voterRepo.GetByID(voterID); err != nil {
+		return nil, fmt.Errorf("voter not found: %v", err)
+	}
+
+	// Validate election ID
+	electionEntity, err := s.electionRepo.GetByID(electionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid election: %v", err)
+	}
+	if err := electionEntity.AcceptsBallot(ballotType, time.Now()); err != nil {
+		return nil, fmt.Errorf("invalid election: %v", err)
+	}
+
+	// A new ballot replaces the earlier one in elections that allow revoting
+	if electionEntity.AllowsRevoting() {
+		return electionEntity, nil
+	}
+
+	// Check if voter has already voted in this election
+	existingBallots, err := s.cardinalBallotRepo.GetByVoterID(voterID, ballotType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing ballots: %v", err)
+	}
+
+	for _, existingBallot := range existingBallots {
+		if existingBallot.ElectionID == electionID {
+			return nil, &voter.AlreadyVotedError{VoterID: voterID, ElectionID: electionID}
+		}
+	}
+
+	return electionEntity, nil
+}
+
+// storeBallot stores a cardinal ballot under a new ID, superseding the voter's earlier ballot if the
+// election allows revoting; the repository marks the voter as having voted in the same transaction
+func (s *CardinalBallotService) storeBallot(electionEntity *election.Election, cardinalBallot *ballot.CardinalBallot, scores []ballot.BallotScore) (*ballot.CardinalBallotResponse, error) {
+	err := ballot.CreateWithNewID(s.ballotIDs, cardinalBallot.IDPrefix(), func(ballotID string) error {
+		cardinalBallot.AssignID(ballotID, scores)
+		if electionEntity.AllowsRevoting() {
+			return s.cardinalBallotRepo.Replace(cardinalBallot, scores)
+		}
+		return s.cardinalBallotRepo.Create(cardinalBallot, scores)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store %s ballot: %w", cardinalBallot.BallotType, err)
+	}
+
+	return cardinalBallot.ToResponse(), nil
+}
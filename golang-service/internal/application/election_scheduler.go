@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+)
+
+// ElectionScheduler opens and closes elections automatically at their configured times
+type ElectionScheduler struct {
+	repo     election.Repository
+	interval time.Duration
+}
+
+// NewElectionScheduler creates a new election scheduler that checks for due elections every interval
+func NewElectionScheduler(repo election.Repository, interval time.Duration) *ElectionScheduler {
+	return &ElectionScheduler{
+		repo:     repo,
+		interval: interval,
+	}
+}
+
+// Run checks for due elections until the context is cancelled
+func (s *ElectionScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce moves every registration election whose opening time has passed into voting,
+// and every voting election whose closing time has passed into closed
+func (s *ElectionScheduler) RunOnce(now time.Time) {
+	elections, err := s.repo.GetByPhase(election.PhaseRegistration, election.PhaseVoting)
+	if err != nil {
+		log.Printf("Warning: election scheduler failed to load elections: %v", err)
+		return
+	}
+
+	for _, e := range elections {
+		phase, due := e.IsDue(now)
+		if !due {
+			continue
+		}
+
+		transition, err := e.TransitionTo(phase, election.ActorScheduler, now)
+		if err != nil {
+			log.Printf("Warning: election scheduler could not advance election %s: %v", e.ElectionID, err)
+			continue
+		}
+
+		// A concurrent transition by an administrator makes this fail, which is fine
+		if err := s.repo.SaveTransition(e, transition); err != nil {
+			log.Printf("Warning: election scheduler could not advance election %s: %v", e.ElectionID, err)
+			continue
+		}
+
+		log.Printf("Election %s moved from %s to %s", e.ElectionID, transition.FromPhase, transition.ToPhase)
+	}
+}
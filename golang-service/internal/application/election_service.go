@@ -0,0 +1,203 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+)
+
+// ElectionService implements the election.Service interface
+type ElectionService struct {
+	repo          election.Repository
+	candidateRepo candidate.Repository
+	tabulators    *ballot.TabulatorRegistry
+}
+
+// NewElectionService creates a new election service that checks official methods against the tabulators
+func NewElectionService(repo election.Repository, candidateRepo candidate.Repository, tabulators *ballot.TabulatorRegistry) election.Service {
+	return &ElectionService{
+		repo:          repo,
+		candidateRepo: candidateRepo,
+		tabulators:    tabulators,
+	}
+}
+
+// CreateElection creates a new election in the draft phase
+func (s *ElectionService) CreateElection(req election.ElectionRequest) (*election.Election, error) {
+	e, err := req.ToElection()
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	e.Method, e.MethodOptions, err = s.tabulators.ResolveMethod(e.BallotType, e.Method, e.MethodOptions)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	exists, err := s.repo.ExistsByID(req.ElectionID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking election existence: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("election with id: %s already exists", req.ElectionID)
+	}
+
+	if err := validateCandidatesExist(s.candidateRepo, e.CandidateIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(e); err != nil {
+		return nil, fmt.Errorf("failed to create election: %w", err)
+	}
+
+	return e, nil
+}
+
+// GetElection retrieves an election by ID
+func (s *ElectionService) GetElection(electionID string) (*election.Election, error) {
+	return s.repo.GetByID(electionID)
+}
+
+// GetAllElections retrieves all elections
+func (s *ElectionService) GetAllElections() (*election.ElectionsListResponse, error) {
+	elections, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if elections == nil {
+		elections = []*election.Election{}
+	}
+
+	return &election.ElectionsListResponse{
+		Elections: elections,
+	}, nil
+}
+
+// UpdateElection updates an existing election.
+// The ballot type, official method, signature mode and candidate slate are frozen once the election leaves draft,
+// and nothing can be edited once voting has started.
+func (s *ElectionService) UpdateElection(electionID string, req election.ElectionRequest) (*election.Election, error) {
+	existingElection, err := s.repo.GetByID(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ElectionID = electionID
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	method, methodOptions, err := s.tabulators.ResolveMethod(req.BallotType, req.Method, req.MethodOptions)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	switch existingElection.Phase {
+	case election.PhaseDraft:
+	case election.PhaseRegistration:
+		if req.BallotType != existingElection.BallotType || req.MaxScore != existingElection.MaxScore ||
+			!existingElection.IsOfficialMethod(method, methodOptions) ||
+			req.StrictSignatures != existingElection.StrictSignatures || req.MaxSelections != existingElection.MaxSelections ||
+			req.SecretBallot != existingElection.SecretBallot || req.RevotePolicy != existingElection.RevotePolicy ||
+			!sameCandidates(req.CandidateIDs, existingElection.CandidateIDs) {
+			return nil, fmt.Errorf("invalid update: ballot_type, max_score, method, strict_signatures, max_selections, secret_ballot, revote_policy and candidate_ids cannot change after the election leaves draft")
+		}
+	default:
+		return nil, fmt.Errorf("invalid update: election %s cannot be edited in phase %s", electionID, existingElection.Phase)
+	}
+
+	if err := validateCandidatesExist(s.candidateRepo, req.CandidateIDs); err != nil {
+		return nil, err
+	}
+
+	updatedElection := &election.Election{
+		ElectionID:       electionID,
+		Title:            req.Title,
+		BallotType:       req.BallotType,
+		MaxScore:         req.MaxScore,
+		CandidateIDs:     req.CandidateIDs,
+		OpensAt:          req.OpensAt,
+		ClosesAt:         req.ClosesAt,
+		Phase:            existingElection.Phase,     // Phase only changes through transitions
+		CreatedAt:        existingElection.CreatedAt, // Preserve created_at
+		Method:           method,
+		MethodOptions:    methodOptions,
+		StrictSignatures: req.StrictSignatures,
+		MaxSelections:    req.MaxSelections,
+		SecretBallot:     req.SecretBallot,
+		RevotePolicy:     req.RevotePolicy,
+	}
+
+	if err := s.repo.Update(updatedElection); err != nil {
+		return nil, fmt.Errorf("failed to update election: %w", err)
+	}
+
+	return updatedElection, nil
+}
+
+// DeleteElection deletes an election by ID. Only draft elections can be deleted.
+func (s *ElectionService) DeleteElection(electionID string) error {
+	existingElection, err := s.repo.GetByID(electionID)
+	if err != nil {
+		return err
+	}
+
+	if existingElection.Phase != election.PhaseDraft {
+		return fmt.Errorf("invalid delete: election %s is in phase %s, only draft elections can be deleted", electionID, existingElection.Phase)
+	}
+
+	return s.repo.Delete(electionID)
+}
+
+// TransitionElection moves an election to the next phase on behalf of an actor
+func (s *ElectionService) TransitionElection(electionID string, req election.TransitionRequest) (*election.Election, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	e, err := s.repo.GetByID(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := e.TransitionTo(req.Phase, req.Actor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveTransition(e, transition); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// GetElectionTransitions retrieves the phase history of an election
+func (s *ElectionService) GetElectionTransitions(electionID string) ([]*election.Transition, error) {
+	exists, err := s.repo.ExistsByID(electionID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking election existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("election with id: %s was not found", electionID)
+	}
+
+	return s.repo.GetTransitions(electionID)
+}
+
+// sameCandidates reports whether two candidate slates are identical, including order
+func sameCandidates(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
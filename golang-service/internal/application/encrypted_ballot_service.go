@@ -2,8 +2,11 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/receipt"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
@@ -11,51 +14,94 @@ import (
 type EncryptedBallotService struct {
 	encryptedBallotRepo ballot.EncryptedBallotRepository
 	voterRepo           voter.Repository
+	voterKeyRepo        voter.KeyRepository
+	electionRepo        election.Repository
+	electionKeyRepo     ballot.ElectionKeyRepository
+	ballotIDs           ballot.IDGenerator
 }
 
 // NewEncryptedBallotService creates a new encrypted ballot service
 func NewEncryptedBallotService(
 	encryptedBallotRepo ballot.EncryptedBallotRepository,
 	voterRepo voter.Repository,
+	voterKeyRepo voter.KeyRepository,
+	electionRepo election.Repository,
+	electionKeyRepo ballot.ElectionKeyRepository,
+	ballotIDs ballot.IDGenerator,
 ) *EncryptedBallotService {
 	return &EncryptedBallotService{
 		encryptedBallotRepo: encryptedBallotRepo,
 		voterRepo:           voterRepo,
+		voterKeyRepo:        voterKeyRepo,
+		electionRepo:        electionRepo,
+		electionKeyRepo:     electionKeyRepo,
+		ballotIDs:           ballotIDs,
 	}
 }
 
 // CreateEncryptedBallot creates a new encrypted ballot
 func (s *EncryptedBallotService) CreateEncryptedBallot(req *ballot.EncryptedBallotRequest) (*ballot.EncryptedBallotResponse, error) {
-	// Validate request
-	if err := req.Validate(); err != nil {
+	// Validate election ID
+	electionEntity, err := s.getBallotElection(req.ElectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid election: %v", err)
+	}
+
+	// Strict elections take every field as sent; other elections keep the lenient validation
+	// that coerces fields to base64 and hex
+	if electionEntity.StrictSignatures {
+		if err := req.ValidateStrict(); err != nil {
+			return nil, fmt.Errorf("validation failed: %v", err)
+		}
+	} else if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	// The key must be enrolled for this voter in this election and not revoked
+	if err := s.checkEnrolledKey(req.ElectionID, req.VoterID, req.VoterPubkey); err != nil {
+		return nil, err
+	}
+
+	// Enrolled keys are public, so only a signature made with the key shows the ballot comes from its voter
+	if err := req.VerifySignature(); err != nil {
 		return nil, fmt.Errorf("validation failed: %v", err)
 	}
 
-	// Check if nullifier already exists (prevent double voting)
+	// Ballots are only taken once the election has a key, and only as well-formed ElGamal ciphertexts
+	// the homomorphic tally can count
+	if err := s.verifyBallot(electionEntity, req); err != nil {
+		return nil, err
+	}
+
+	// Check if voter has already voted in this election; a fresh nullifier does not make a second ballot
+	hasVoted, err := s.encryptedBallotRepo.HasVoted(req.ElectionID, req.VoterID)
+	if err != nil {
+		return nil, err
+	}
+	if hasVoted {
+		return nil, &voter.AlreadyVotedError{VoterID: req.VoterID, ElectionID: req.ElectionID}
+	}
+
+	// Check if nullifier already exists (prevent replaying a ballot)
 	existingBallot, err := s.encryptedBallotRepo.GetByNullifier(req.Nullifier)
 	if err == nil && existingBallot != nil {
 		return nil, fmt.Errorf("nullifier already used: double voting prevented")
 	}
 
-	// In a real implementation, we would:
-	// 1. Verify the ZK proof
-	// 2. Verify the signature
-	// 3. Validate the voter's public key
-	// 4. Check election validity and timing
-	// For this MVP, we'll skip cryptographic verification
-
-	// For encrypted ballots, we use the voter_id from the request
-	// In a real system, this would be validated against the cryptographic proof
-
 	// Convert request to domain model
 	encryptedBallot, err := req.ToEncryptedBallot()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create encrypted ballot: %v", err)
 	}
 
-	// Store the encrypted ballot
-	if err := s.encryptedBallotRepo.Create(encryptedBallot); err != nil {
-		return nil, fmt.Errorf("failed to store encrypted ballot: %v", err)
+	// Store the encrypted ballot under a new ID
+	err = ballot.CreateWithNewID(s.ballotIDs, ballot.EncryptedBallotIDPrefix, func(ballotID string) error {
+		encryptedBallot.BallotID = ballotID
+		encryptedBallot.TrackingCode = receipt.EncryptedBallotCode(encryptedBallot)
+		return s.encryptedBallotRepo.Create(encryptedBallot)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store encrypted ballot: %w", err)
 	}
 
 	return encryptedBallot.ToResponse(), nil
@@ -79,45 +125,76 @@ func (s *EncryptedBallotService) GetEncryptedBallotsByElection(electionID string
 	return s.encryptedBallotRepo.GetByElectionID(electionID)
 }
 
-// ValidateElectionID validates election ID format and timing
+// ValidateElectionID verifies that the election exists and is accepting encrypted ballots
 func (s *EncryptedBallotService) ValidateElectionID(electionID string) error {
+	_, err := s.getBallotElection(electionID)
+	return err
+}
+
+// getBallotElection loads an election that is accepting encrypted ballots
+func (s *EncryptedBallotService) getBallotElection(electionID string) (*election.Election, error) {
 	if electionID == "" {
-		return fmt.Errorf("election_id is required")
+		return nil, fmt.Errorf("election_id is required")
 	}
 
-	// Basic validation - in production, check against election table
-	// and verify election is active
-	if len(electionID) < 3 {
-		return fmt.Errorf("invalid election_id format")
+	electionEntity, err := s.electionRepo.GetByID(electionID)
+	if err != nil {
+		return nil, err
 	}
 
+	if err := electionEntity.AcceptsBallot(election.BallotTypeEncrypted, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return electionEntity, nil
+}
+
+// checkEnrolledKey returns an error unless the public key is an unrevoked key the voter enrolled in the election.
+// A key enrolled by another voter is reported the same way as one that was never enrolled.
+func (s *EncryptedBallotService) checkEnrolledKey(electionID string, voterID int, publicKey string) error {
+	key, err := s.voterKeyRepo.GetActiveByPublicKey(electionID, voter.NormalizePublicKey(publicKey))
+	if err != nil {
+		return fmt.Errorf("failed to check voter key: %v", err)
+	}
+	if key == nil || key.VoterID != voterID {
+		return fmt.Errorf("validation failed: voter_pubkey is not an enrolled key of voter %d in election %s", voterID, electionID)
+	}
 	return nil
 }
 
-// extractVoterIDFromPubkey extracts voter ID from public key
-// This is a simplified implementation - in reality, you would:
-// 1. Verify the signature against the public key
-// 2. Look up the voter by their registered public key
-// 3. Use cryptographic proof to verify voter identity
-func (s *EncryptedBallotService) extractVoterIDFromPubkey(pubkey string) (int, error) {
-	// Simplified: extract voter ID from hex pubkey
-	// In reality, this would be a database lookup or cryptographic derivation
-	if len(pubkey) < 10 {
-		return 0, fmt.Errorf("invalid public key format")
+// verifyBallot returns an error unless the election has a key, the ciphertext holds one ElGamal ciphertext
+// per candidate of the election slate, and zk_proof proves each encrypts 0 or 1 and at most max_selections are 1.
+// Without a key a ballot could be neither verified nor counted, so it is refused rather than stored.
+func (s *EncryptedBallotService) verifyBallot(electionEntity *election.Election, req *ballot.EncryptedBallotRequest) error {
+	key, err := s.electionKeyRepo.GetByElectionID(electionEntity.ElectionID)
+	if err != nil {
+		return fmt.Errorf("failed to check election key: %v", err)
+	}
+	if key == nil {
+		return fmt.Errorf("invalid election: election %s has no key yet, so it cannot accept encrypted ballots", electionEntity.ElectionID)
 	}
 
-	// For MVP, we'll use a simple hash of the pubkey to derive voter ID
-	// In production, you'd have a voter_pubkeys table or similar
-	hash := 0
-	for _, char := range pubkey {
-		hash = (hash*31 + int(char)) % 10
+	candidates := len(electionEntity.CandidateIDs)
+	ciphertext, err := ballot.DecodeBallotCiphertext(req.Ciphertext, candidates)
+	if err != nil {
+		return fmt.Errorf("validation failed: %v", err)
+	}
+	proof, err := ballot.DecodeBallotProof(req.ZKProof, candidates, electionEntity.MaxSelections)
+	if err != nil {
+		return fmt.Errorf("validation failed: %v", err)
 	}
 
-	// Map to existing voter IDs (always use 2 since that's what exists) for testing
-	voterID := 2 // Use existing voter ID 2
-	if voterID <= 0 {
-		voterID = 2
+	publicKey, err := key.ParsePublicKey()
+	if err != nil {
+		return err
+	}
+	voterPubkey, err := ballot.ParseEd25519PublicKey(voter.NormalizePublicKey(req.VoterPubkey))
+	if err != nil {
+		return fmt.Errorf("validation failed: %v", err)
+	}
+	if err := ballot.VerifyBallotProof(electionEntity.ElectionID, voterPubkey, publicKey, ciphertext, proof, electionEntity.MaxSelections); err != nil {
+		return fmt.Errorf("validation failed: %v", err)
 	}
 
-	return voterID, nil
+	return nil
 }
@@ -0,0 +1,266 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+)
+
+// memoryEncryptedBallots stores encrypted ballots and, like the encrypted_ballots indexes, refuses
+// a reused nullifier or a second accepted ballot from a voter in an election
+type memoryEncryptedBallots struct {
+	ballots []*ballot.EncryptedBallot
+}
+
+func (m *memoryEncryptedBallots) Create(encryptedBallot *ballot.EncryptedBallot) error {
+	for _, stored := range m.ballots {
+		if stored.Nullifier == encryptedBallot.Nullifier {
+			return fmt.Errorf("duplicate nullifier: ballot already submitted for this voter")
+		}
+		if stored.ElectionID == encryptedBallot.ElectionID && stored.VoterID == encryptedBallot.VoterID &&
+			stored.Status == ballot.BallotStatusAccepted && encryptedBallot.Status == ballot.BallotStatusAccepted {
+			return &voter.AlreadyVotedError{VoterID: encryptedBallot.VoterID, ElectionID: encryptedBallot.ElectionID}
+		}
+	}
+	stored := *encryptedBallot
+	m.ballots = append(m.ballots, &stored)
+	return nil
+}
+
+func (m *memoryEncryptedBallots) GetByBallotID(ballotID string) (*ballot.EncryptedBallot, error) {
+	for _, stored := range m.ballots {
+		if stored.BallotID == ballotID {
+			return stored, nil
+		}
+	}
+	return nil, fmt.Errorf("encrypted ballot not found: %s", ballotID)
+}
+
+func (m *memoryEncryptedBallots) GetByNullifier(nullifier string) (*ballot.EncryptedBallot, error) {
+	for _, stored := range m.ballots {
+		if stored.Nullifier == nullifier {
+			return stored, nil
+		}
+	}
+	return nil, fmt.Errorf("encrypted ballot not found for nullifier: %s", nullifier)
+}
+
+func (m *memoryEncryptedBallots) HasVoted(electionID string, voterID int) (bool, error) {
+	for _, stored := range m.ballots {
+		if stored.ElectionID == electionID && stored.VoterID == voterID && stored.Status == ballot.BallotStatusAccepted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryEncryptedBallots) GetByElectionID(electionID string) ([]*ballot.EncryptedBallot, error) {
+	var ballots []*ballot.EncryptedBallot
+	for _, stored := range m.ballots {
+		if stored.ElectionID == electionID {
+			ballots = append(ballots, stored)
+		}
+	}
+	return ballots, nil
+}
+
+// singleElection serves one election; other repository methods are not used by ballot submission
+type singleElection struct {
+	election.Repository
+	election *election.Election
+}
+
+func (r *singleElection) GetByID(electionID string) (*election.Election, error) {
+	if electionID != r.election.ElectionID {
+		return nil, fmt.Errorf("election with id: %s was not found", electionID)
+	}
+	return r.election, nil
+}
+
+// enrolledKeys serves the voter keys enrolled in an election
+type enrolledKeys struct {
+	voter.KeyRepository
+	keys []*voter.Key
+}
+
+func (r *enrolledKeys) GetActiveByPublicKey(electionID, publicKey string) (*voter.Key, error) {
+	for _, key := range r.keys {
+		if key.ElectionID == electionID && key.PublicKey == publicKey {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+// singleElectionKey serves the key of one election
+type singleElectionKey struct {
+	key *ballot.ElectionKey
+}
+
+func (r *singleElectionKey) Create(key *ballot.ElectionKey) error {
+	r.key = key
+	return nil
+}
+
+func (r *singleElectionKey) GetByElectionID(electionID string) (*ballot.ElectionKey, error) {
+	if r.key == nil || r.key.ElectionID != electionID {
+		return nil, nil
+	}
+	return r.key, nil
+}
+
+// encryptedBallotRequest encrypts, proves and signs a ballot selecting the candidates at the given positions
+func encryptedBallotRequest(t *testing.T, e *election.Election, key *ballot.ElectionKey, voterID int, voterKey ed25519.PrivateKey, votes []int) *ballot.EncryptedBallotRequest {
+	t.Helper()
+
+	voterPubkey := voterKey.Public().(ed25519.PublicKey)
+
+	publicKey, err := key.ParsePublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, nonces, err := ballot.EncryptBallot(publicKey, votes, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := ballot.ProveBallot(e.ElectionID, voterPubkey, publicKey, ciphertext, votes, nonces, e.MaxSelections, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nullifier := make([]byte, 16)
+	if _, err := rand.Read(nullifier); err != nil {
+		t.Fatal(err)
+	}
+
+	message := ballot.EncryptedBallotSigningMessage(e.ElectionID, ciphertext.Bytes(), proof.Bytes(), nullifier)
+	return &ballot.EncryptedBallotRequest{
+		ElectionID:  e.ElectionID,
+		VoterID:     voterID,
+		Ciphertext:  base64.StdEncoding.EncodeToString(ciphertext.Bytes()),
+		ZKProof:     base64.StdEncoding.EncodeToString(proof.Bytes()),
+		VoterPubkey: hex.EncodeToString(voterPubkey),
+		Nullifier:   hex.EncodeToString(nullifier),
+		Signature:   base64.StdEncoding.EncodeToString(ed25519.Sign(voterKey, message)),
+	}
+}
+
+// newEncryptedElection returns an encrypted election in voting, its key and a service over in-memory
+// repositories with voterKey enrolled for voter 7
+func newEncryptedElection(t *testing.T, voterKey ed25519.PrivateKey) (*election.Election, *ballot.ElectionKey, *memoryEncryptedBallots, *EncryptedBallotService) {
+	t.Helper()
+
+	e := &election.Election{
+		ElectionID:    "election-1",
+		BallotType:    election.BallotTypeEncrypted,
+		CandidateIDs:  []int{1, 2, 3},
+		MaxSelections: 1,
+		Phase:         election.PhaseVoting,
+		ClosesAt:      time.Now().Add(time.Hour),
+	}
+	key, err := ballot.GenerateElectionKey(e.ElectionID, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ballots := &memoryEncryptedBallots{}
+	voterPubkey := voterKey.Public().(ed25519.PublicKey)
+	service := NewEncryptedBallotService(
+		ballots,
+		nil,
+		&enrolledKeys{keys: []*voter.Key{{VoterID: 7, ElectionID: e.ElectionID, PublicKey: hex.EncodeToString(voterPubkey)}}},
+		&singleElection{election: e},
+		&singleElectionKey{key: key},
+		ballot.NewRandomIDGenerator(),
+	)
+	return e, key, ballots, service
+}
+
+// newVoterKey generates a voter's Ed25519 key
+func newVoterKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, voterKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return voterKey
+}
+
+func TestCreateEncryptedBallotRequiresSignatureByEnrolledKey(t *testing.T) {
+	voterKey := newVoterKey(t)
+	e, key, ballots, service := newEncryptedElection(t, voterKey)
+
+	// Anyone can read the enrolled public key and prove a ballot bound to it, but not sign it
+	forged := encryptedBallotRequest(t, e, key, 7, voterKey, []int{1, 0, 0})
+	message, err := forged.SigningMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(newVoterKey(t), message))
+	if _, err := service.CreateEncryptedBallot(forged); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Fatalf("got %v for a ballot not signed by the enrolled key, expected a signature error", err)
+	}
+	if len(ballots.ballots) != 0 {
+		t.Fatalf("stored %d ballots, expected none", len(ballots.ballots))
+	}
+
+	if _, err := service.CreateEncryptedBallot(encryptedBallotRequest(t, e, key, 7, voterKey, []int{1, 0, 0})); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateEncryptedBallotRejectsSecondBallotFromVoter(t *testing.T) {
+	voterKey := newVoterKey(t)
+	e, key, ballots, service := newEncryptedElection(t, voterKey)
+
+	first := encryptedBallotRequest(t, e, key, 7, voterKey, []int{1, 0, 0})
+	if _, err := service.CreateEncryptedBallot(first); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second ballot is well formed and has a fresh nullifier, but comes from the same voter
+	second := encryptedBallotRequest(t, e, key, 7, voterKey, []int{0, 1, 0})
+	_, err := service.CreateEncryptedBallot(second)
+	var alreadyVoted *voter.AlreadyVotedError
+	if !errors.As(err, &alreadyVoted) {
+		t.Fatalf("got %v for the voter's second ballot, expected an AlreadyVotedError", err)
+	}
+
+	stored, err := ballots.GetByElectionID(e.ElectionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregate, counted, err := ballot.AggregateBallots(stored, len(e.CandidateIDs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counted != 1 {
+		t.Fatalf("counted %d ballots, expected 1", counted)
+	}
+
+	secret, err := key.ParseSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := ballot.ElGamalGroup
+	for i, want := range []int{1, 0, 0} {
+		total, err := g.DecryptWithFactor(aggregate[i], g.DecryptionFactor(aggregate[i], secret), counted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != want {
+			t.Fatalf("candidate %d has %d votes, expected %d", e.CandidateIDs[i], total, want)
+		}
+	}
+}
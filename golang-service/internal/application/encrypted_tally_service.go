@@ -0,0 +1,230 @@
+package application
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/trustee"
+)
+
+// EncryptedTallyService manages election keys and tallies encrypted ballots homomorphically
+type EncryptedTallyService struct {
+	electionRepo        election.Repository
+	encryptedBallotRepo ballot.EncryptedBallotRepository
+	electionKeyRepo     ballot.ElectionKeyRepository
+	trusteeRepo         trustee.Repository
+}
+
+// NewEncryptedTallyService creates a new encrypted tally service
+func NewEncryptedTallyService(
+	electionRepo election.Repository,
+	encryptedBallotRepo ballot.EncryptedBallotRepository,
+	electionKeyRepo ballot.ElectionKeyRepository,
+	trusteeRepo trustee.Repository,
+) *EncryptedTallyService {
+	return &EncryptedTallyService{
+		electionRepo:        electionRepo,
+		encryptedBallotRepo: encryptedBallotRepo,
+		electionKeyRepo:     electionKeyRepo,
+		trusteeRepo:         trusteeRepo,
+	}
+}
+
+// CreateElectionKey generates the key pair ballots of an encrypted election are encrypted under.
+// The key is created before voting opens, while the election is in draft or registration.
+// Only the public key is stored; the returned secret key is the operator's to keep, and is
+// needed to decrypt the tally. Elections whose trustees generate the key have no single key pair.
+func (s *EncryptedTallyService) CreateElectionKey(electionID string) (*ballot.ElectionKey, error) {
+	electionEntity, err := s.getEncryptedElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if electionEntity.Phase != election.PhaseDraft && electionEntity.Phase != election.PhaseRegistration {
+		return nil, fmt.Errorf("election keys cannot be created for election %s in phase %s", electionID, electionEntity.Phase)
+	}
+
+	ceremony, err := s.trusteeRepo.GetCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony != nil {
+		return nil, fmt.Errorf("election %s already has a trustee ceremony", electionID)
+	}
+
+	key, err := ballot.GenerateElectionKey(electionID, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate election key: %v", err)
+	}
+	if err := s.electionKeyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetElectionKey retrieves the public key of an election
+func (s *EncryptedTallyService) GetElectionKey(electionID string) (*ballot.ElectionKey, error) {
+	if _, err := s.getEncryptedElection(electionID); err != nil {
+		return nil, err
+	}
+
+	return s.getElectionKey(electionID)
+}
+
+// GetEncryptedTally multiplies every accepted ballot of a closed election into one aggregate
+// ciphertext per candidate and decrypts only the aggregate, giving per-candidate totals
+// without decrypting any individual ballot. A single key is decrypted with the secret key the
+// operator supplies, and a key generated by trustees by combining the decryption shares of
+// threshold trustees.
+func (s *EncryptedTallyService) GetEncryptedTally(electionID string, req ballot.TallyRequest) (*ballot.EncryptedTally, error) {
+	electionEntity, err := s.getEncryptedElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := electionEntity.AllowsResults(); err != nil {
+		return nil, err
+	}
+
+	key, err := s.getElectionKey(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots, err := s.encryptedBallotRepo.GetByElectionID(electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encrypted ballots: %v", err)
+	}
+	aggregate, counted, err := ballot.AggregateBallots(ballots, len(electionEntity.CandidateIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	var factors []*big.Int
+	if key.IsThreshold() {
+		if req.SecretKey != "" {
+			return nil, fmt.Errorf("validation failed: election %s is decrypted by its trustees and has no secret_key", electionID)
+		}
+		factors, err = s.combineDecryptionShares(electionID, key, aggregate)
+	} else {
+		factors, err = singleKeyFactors(key, req.SecretKey, aggregate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Each ballot adds 0 or 1 per candidate, so no total exceeds the number of ballots
+	totals := make([]ballot.CandidateTotal, len(aggregate))
+	for i, candidateID := range electionEntity.CandidateIDs {
+		votes, err := ballot.ElGamalGroup.DecryptWithFactor(aggregate[i], factors[i], counted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt total of candidate %d: %v", candidateID, err)
+		}
+		totals[i] = ballot.CandidateTotal{CandidateID: candidateID, Votes: votes}
+	}
+
+	return &ballot.EncryptedTally{
+		EncryptedAggregate: ballot.EncryptedAggregate{
+			ElectionID: electionID,
+			Ballots:    counted,
+			Aggregate:  base64.StdEncoding.EncodeToString(aggregate.Bytes()),
+		},
+		Totals: totals,
+	}, nil
+}
+
+// singleKeyFactors computes the decryption factors of the aggregate with the secret key the operator supplied
+func singleKeyFactors(key *ballot.ElectionKey, secretKey string, aggregate ballot.BallotCiphertext) ([]*big.Int, error) {
+	if secretKey == "" {
+		return nil, fmt.Errorf("validation failed: secret_key is required to decrypt the tally of election %s", key.ElectionID)
+	}
+	secret, err := key.CheckSecretKey(secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	factors := make([]*big.Int, len(aggregate))
+	for i, ciphertext := range aggregate {
+		factors[i] = ballot.ElGamalGroup.DecryptionFactor(ciphertext, secret)
+	}
+	return factors, nil
+}
+
+// combineDecryptionShares combines the decryption shares of the first threshold trustees whose
+// proofs verify against the aggregate
+func (s *EncryptedTallyService) combineDecryptionShares(electionID string, key *ballot.ElectionKey, aggregate ballot.BallotCiphertext) ([]*big.Int, error) {
+	ceremony, err := s.trusteeRepo.GetCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil {
+		return nil, fmt.Errorf("trustee ceremony for election %s was not found", electionID)
+	}
+
+	shares, err := s.trusteeRepo.GetDecryptionShares(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptions := make([]*trustee.PartialDecryption, 0, key.Threshold)
+	for _, share := range shares {
+		if len(decryptions) == key.Threshold {
+			break
+		}
+
+		t, err := ceremony.Trustee(share.TrusteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		verificationKey, err := t.ParseVerificationKey()
+		if err != nil {
+			return nil, err
+		}
+		decryption, err := share.ToPartialDecryption()
+		if err != nil {
+			continue
+		}
+		if decryption.Verify(electionID, verificationKey, aggregate) != nil {
+			continue
+		}
+		decryptions = append(decryptions, decryption)
+	}
+
+	if len(decryptions) < key.Threshold {
+		return nil, fmt.Errorf("validation failed: %d valid decryption shares submitted, %d are required", len(decryptions), key.Threshold)
+	}
+
+	return trustee.CombineDecryptions(decryptions, len(aggregate))
+}
+
+// getEncryptedElection loads an election that accepts encrypted ballots
+func (s *EncryptedTallyService) getEncryptedElection(electionID string) (*election.Election, error) {
+	if electionID == "" {
+		return nil, fmt.Errorf("election_id is required")
+	}
+
+	electionEntity, err := s.electionRepo.GetByID(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if electionEntity.BallotType != election.BallotTypeEncrypted {
+		return nil, fmt.Errorf("invalid election: election %s does not accept %s ballots", electionID, election.BallotTypeEncrypted)
+	}
+
+	return electionEntity, nil
+}
+
+// getElectionKey loads the key pair of an election, which must have one
+func (s *EncryptedTallyService) getElectionKey(electionID string) (*ballot.ElectionKey, error) {
+	key, err := s.electionKeyRepo.GetByElectionID(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("election key for election %s was not found", electionID)
+	}
+	return key, nil
+}
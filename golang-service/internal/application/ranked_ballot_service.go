@@ -2,8 +2,12 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/receipt"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
@@ -11,16 +15,25 @@ import (
 type RankedBallotService struct {
 	rankedBallotRepo ballot.RankedBallotRepository
 	voterRepo        voter.Repository
+	electionRepo     election.Repository
+	candidateRepo    candidate.Repository
+	ballotIDs        ballot.IDGenerator
 }
 
 // NewRankedBallotService creates a new ranked ballot service
 func NewRankedBallotService(
 	rankedBallotRepo ballot.RankedBallotRepository,
 	voterRepo voter.Repository,
+	electionRepo election.Repository,
+	candidateRepo candidate.Repository,
+	ballotIDs ballot.IDGenerator,
 ) *RankedBallotService {
 	return &RankedBallotService{
 		rankedBallotRepo: rankedBallotRepo,
 		voterRepo:        voterRepo,
+		electionRepo:     electionRepo,
+		candidateRepo:    candidateRepo,
+		ballotIDs:        ballotIDs,
 	}
 }
 
@@ -32,47 +45,56 @@ func (s *RankedBallotService) CreateRankedBallot(req *ballot.RankedBallotRequest
 	}
 
 	// Verify voter exists
-	voterEntity, err := s.voterRepo.GetByID(req.VoterID)
+	_, err := s.voterRepo.GetByID(req.VoterID)
 	if err != nil {
 		return nil, fmt.Errorf("voter not found: %v", err)
 	}
 
-	// Check if voter has already voted in this election
-	existingBallots, err := s.rankedBallotRepo.GetByVoterID(req.VoterID)
+	// Validate election ID
+	electionEntity, err := s.getBallotElection(req.ElectionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check existing ballots: %v", err)
+		return nil, fmt.Errorf("invalid election: %v", err)
 	}
 
-	for _, existingBallot := range existingBallots {
-		if existingBallot.ElectionID == req.ElectionID {
-			return nil, fmt.Errorf("voter %d has already voted in election %s", req.VoterID, req.ElectionID)
+	// Check if voter has already voted in this election, unless a new ballot replaces the earlier one
+	if !electionEntity.AllowsRevoting() {
+		if err := s.checkNotVoted(electionEntity, req.VoterID); err != nil {
+			return nil, err
 		}
 	}
 
-	// Validate election ID
-	if err := s.ValidateElectionID(req.ElectionID); err != nil {
-		return nil, fmt.Errorf("invalid election: %v", err)
+	// Validate that every ranked candidate exists and is on the ballot
+	if err := validateBallotCandidates(s.candidateRepo, electionEntity, req.Ranking.Candidates()); err != nil {
+		return nil, err
 	}
 
-	// TODO: In a real implementation, validate that all candidate IDs in ranking exist
-	// For now, we'll assume they're valid
-
 	// Convert request to domain model
 	rankedBallot, rankings, err := req.ToRankedBallot()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ranked ballot: %v", err)
 	}
 
-	// Store the ranked ballot with its rankings
-	if err := s.rankedBallotRepo.Create(rankedBallot, rankings); err != nil {
-		return nil, fmt.Errorf("failed to store ranked ballot: %v", err)
+	// Secret ballots are stored without their voter, whose participation is recorded apart
+	var participation *ballot.Participation
+	if electionEntity.SecretBallot {
+		participation = rankedBallot.Anonymize()
 	}
 
-	// Update voter's has_voted status
-	voterEntity.HasVoted = true
-	if err := s.voterRepo.Update(voterEntity); err != nil {
-		// Log the error but don't fail the ballot creation
-		fmt.Printf("Warning: failed to update voter has_voted status: %v\n", err)
+	// Store the ranked ballot with its rankings under a new ID; the repository marks the voter
+	// as having voted in the same transaction
+	err = ballot.CreateWithNewID(s.ballotIDs, ballot.RankedBallotIDPrefix, func(ballotID string) error {
+		rankedBallot.AssignID(ballotID, rankings)
+		rankedBallot.TrackingCode = receipt.RankedBallotCode(rankedBallot, rankings)
+		if participation != nil {
+			return s.rankedBallotRepo.CreateSecret(rankedBallot, rankings, participation)
+		}
+		if electionEntity.AllowsRevoting() {
+			return s.rankedBallotRepo.Replace(rankedBallot, rankings)
+		}
+		return s.rankedBallotRepo.Create(rankedBallot, rankings)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store ranked ballot: %w", err)
 	}
 
 	return rankedBallot.ToResponse(), nil
@@ -96,35 +118,51 @@ func (s *RankedBallotService) GetRankedBallotsByElection(electionID string) ([]b
 	return s.rankedBallotRepo.GetByElectionID(electionID)
 }
 
-// CalculateSchulzeWinner calculates the Schulze method winner for an election
-func (s *RankedBallotService) CalculateSchulzeWinner(electionID string) (*ballot.SchulzeResult, error) {
+// VerifyPairwiseTally rebuilds the pairwise tally of an election from its ballots and
+// reports every count where the stored tally has drifted from it
+func (s *RankedBallotService) VerifyPairwiseTally(electionID string) (*ballot.TallyVerification, error) {
 	if electionID == "" {
 		return nil, fmt.Errorf("election_id is required")
 	}
 
-	// Get all ranked ballots for the election
-	ballots, err := s.rankedBallotRepo.GetByElectionID(electionID)
+	stored, err := s.rankedBallotRepo.GetPairwiseTally(electionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ranked ballots: %v", err)
+		return nil, fmt.Errorf("failed to get pairwise tally: %v", err)
 	}
 
-	if len(ballots) == 0 {
-		return &ballot.SchulzeResult{
-			ElectionID: electionID,
-			Winners:    []int{},
-			Rankings:   []ballot.SchulzeCandidateRank{},
-		}, nil
+	rebuilt := ballot.NewPairwiseTally()
+	count := 0
+	err = s.rankedBallotRepo.StreamByElectionID(electionID, func(rankedBallot ballot.RankedBallotWithRankings) error {
+		rebuilt.Add(rankedBallot.Rankings)
+		count++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ranked ballots: %v", err)
 	}
 
-	// Calculate Schulze winner using the algorithm
-	result := ballot.CalculateSchulze(ballots)
-	result.ElectionID = electionID
+	// Ballots cast while rebuilding change the stored tally; the comparison is only
+	// meaningful if it is unchanged from before the ballots were read
+	after, err := s.rankedBallotRepo.GetPairwiseTally(electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pairwise tally: %v", err)
+	}
+	if len(ballot.CompareTallies(stored, after)) > 0 {
+		return nil, fmt.Errorf("pairwise tally of election %s changed during verification, retry once voting has closed", electionID)
+	}
 
-	return result, nil
+	drift := ballot.CompareTallies(stored, rebuilt)
+	return &ballot.TallyVerification{
+		ElectionID: electionID,
+		Ballots:    count,
+		Consistent: len(drift) == 0,
+		Drift:      drift,
+	}, nil
 }
 
-// GetVoterBallots retrieves all ballots for a specific voter
-func (s *RankedBallotService) GetVoterBallots(voterID int) ([]*ballot.RankedBallot, error) {
+// GetVoterParticipation retrieves the ranked elections a voter cast a ballot in. It reports only
+// participation, never the voter's ballots, so it cannot reveal how a voter ranked the candidates.
+func (s *RankedBallotService) GetVoterParticipation(voterID int) (*ballot.ParticipationResponse, error) {
 	if voterID <= 0 {
 		return nil, fmt.Errorf("voter_id must be positive")
 	}
@@ -135,40 +173,65 @@ func (s *RankedBallotService) GetVoterBallots(voterID int) ([]*ballot.RankedBall
 		return nil, fmt.Errorf("voter not found: %v", err)
 	}
 
-	return s.rankedBallotRepo.GetByVoterID(voterID)
+	participation, err := s.rankedBallotRepo.GetParticipation(voterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ballot.ParticipationResponse{
+		VoterID:       voterID,
+		Participation: participation,
+		Count:         len(participation),
+	}, nil
 }
 
-// ValidateElectionID validates election ID format and timing
-func (s *RankedBallotService) ValidateElectionID(electionID string) error {
-	if electionID == "" {
-		return fmt.Errorf("election_id is required")
+// checkNotVoted returns an error if the voter has already voted in the election. Secret-ballot
+// elections look up the voter's participation, since their ballots have no voter.
+func (s *RankedBallotService) checkNotVoted(electionEntity *election.Election, voterID int) error {
+	if electionEntity.SecretBallot {
+		participated, err := s.rankedBallotRepo.HasParticipated(electionEntity.ElectionID, voterID)
+		if err != nil {
+			return fmt.Errorf("failed to check existing ballots: %v", err)
+		}
+		if participated {
+			return &voter.AlreadyVotedError{VoterID: voterID, ElectionID: electionEntity.ElectionID}
+		}
+		return nil
 	}
 
-	// Basic validation - in production, check against election table
-	// and verify election is active and accepting votes
-	if len(electionID) < 3 {
-		return fmt.Errorf("invalid election_id format")
+	existingBallots, err := s.rankedBallotRepo.GetByVoterID(voterID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing ballots: %v", err)
 	}
 
-	// TODO: In production, verify:
-	// 1. Election exists
-	// 2. Election is currently active (within voting period)
-	// 3. Election accepts ranked ballots
-
+	for _, existingBallot := range existingBallots {
+		if existingBallot.ElectionID == electionEntity.ElectionID {
+			return &voter.AlreadyVotedError{VoterID: voterID, ElectionID: electionEntity.ElectionID}
+		}
+	}
 	return nil
 }
 
-// GetElectionResults provides comprehensive election results including Schulze analysis
-func (s *RankedBallotService) GetElectionResults(electionID string) (*ballot.SchulzeResult, error) {
+// ValidateElectionID verifies that the election exists and is accepting ranked ballots
+func (s *RankedBallotService) ValidateElectionID(electionID string) error {
+	_, err := s.getBallotElection(electionID)
+	return err
+}
+
+// getBallotElection loads the election and verifies it is accepting ranked ballots
+func (s *RankedBallotService) getBallotElection(electionID string) (*election.Election, error) {
 	if electionID == "" {
 		return nil, fmt.Errorf("election_id is required")
 	}
 
-	// Calculate Schulze results
-	results, err := s.CalculateSchulzeWinner(electionID)
+	electionEntity, err := s.electionRepo.GetByID(electionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate election results: %v", err)
+		return nil, err
+	}
+
+	if err := electionEntity.AcceptsBallot(election.BallotTypeRanked, time.Now()); err != nil {
+		return nil, err
 	}
 
-	return results, nil
+	return electionEntity, nil
 }
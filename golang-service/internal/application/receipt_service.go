@@ -0,0 +1,39 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/receipt"
+)
+
+// ReceiptService looks up cast receipts by tracking code
+type ReceiptService struct {
+	receiptRepo receipt.Repository
+}
+
+// NewReceiptService creates a new receipt service
+func NewReceiptService(receiptRepo receipt.Repository) *ReceiptService {
+	return &ReceiptService{receiptRepo: receiptRepo}
+}
+
+// GetReceipt retrieves the receipt of the ballot with a tracking code
+func (s *ReceiptService) GetReceipt(code string) (*receipt.Receipt, error) {
+	if code == "" {
+		return nil, fmt.Errorf("tracking code is required")
+	}
+
+	normalized, err := receipt.NormalizeCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := s.receiptRepo.GetByTrackingCode(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("receipt %s was not found", normalized)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+)
+
+// ResultsService tabulates election results with the registered counting methods
+type ResultsService struct {
+	electionRepo       election.Repository
+	rankedBallotRepo   ballot.RankedBallotRepository
+	cardinalBallotRepo ballot.CardinalBallotRepository
+	tabulators         *ballot.TabulatorRegistry
+}
+
+// NewResultsService creates a new election results service
+func NewResultsService(
+	electionRepo election.Repository,
+	rankedBallotRepo ballot.RankedBallotRepository,
+	cardinalBallotRepo ballot.CardinalBallotRepository,
+	tabulators *ballot.TabulatorRegistry,
+) *ResultsService {
+	return &ResultsService{
+		electionRepo:       electionRepo,
+		rankedBallotRepo:   rankedBallotRepo,
+		cardinalBallotRepo: cardinalBallotRepo,
+		tabulators:         tabulators,
+	}
+}
+
+// GetTabulators lists every registered counting method with its options
+func (s *ResultsService) GetTabulators() []ballot.TabulatorDescription {
+	return s.tabulators.Describe()
+}
+
+// GetElectionResults tabulates the ballots of an election once results may be published.
+// Without a method the election's official method and options are used; any other method
+// or options give unofficial results.
+func (s *ResultsService) GetElectionResults(electionID, method string, options map[string]string) (*ballot.TabulationResult, error) {
+	if electionID == "" {
+		return nil, fmt.Errorf("election_id is required")
+	}
+
+	electionEntity, err := s.electionRepo.GetByID(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := electionEntity.AllowsResults(); err != nil {
+		return nil, err
+	}
+
+	if method == "" {
+		if len(options) > 0 {
+			return nil, fmt.Errorf("invalid method options: a method is required when options are given")
+		}
+		if electionEntity.Method == "" {
+			return nil, fmt.Errorf("invalid method: election %s uses %s ballots, which have no counting method", electionID, electionEntity.BallotType)
+		}
+		method, options = electionEntity.Method, electionEntity.MethodOptions
+	}
+
+	method, options, err = s.tabulators.ResolveMethod(electionEntity.BallotType, method, options)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	tabulator, err := s.tabulators.Get(method)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := s.loadBallots(electionEntity, tabulator)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tabulator.Tabulate(input, options)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	return &ballot.TabulationResult{
+		ElectionID: electionID,
+		Method:     method,
+		Official:   electionEntity.IsOfficialMethod(method, options),
+		Options:    options,
+		Result:     result,
+	}, nil
+}
+
+// loadBallots loads the ballots of an election for its ballot type.
+// A tabulator that supports it gets the stored pairwise tally and streamed ranked ballots instead.
+func (s *ResultsService) loadBallots(electionEntity *election.Election, tabulator ballot.Tabulator) (*ballot.TabulationInput, error) {
+	input := &ballot.TabulationInput{
+		ElectionID: electionEntity.ElectionID,
+		MaxScore:   electionEntity.MaxScore,
+	}
+
+	var err error
+	switch electionEntity.BallotType {
+	case election.BallotTypeRanked:
+		if streaming, ok := tabulator.(ballot.StreamingTabulator); ok && streaming.Streams() {
+			electionID := electionEntity.ElectionID
+			input.RankedSource = func(yield func(ballot.RankedBallotWithRankings) error) error {
+				if err := s.rankedBallotRepo.StreamByElectionID(electionID, yield); err != nil {
+					return fmt.Errorf("failed to get ranked ballots: %v", err)
+				}
+				return nil
+			}
+			input.PairwiseTally, err = s.rankedBallotRepo.GetPairwiseTally(electionID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pairwise tally: %v", err)
+			}
+			break
+		}
+		input.RankedBallots, err = s.rankedBallotRepo.GetByElectionID(electionEntity.ElectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ranked ballots: %v", err)
+		}
+	case election.BallotTypeApproval, election.BallotTypeScore:
+		input.CardinalBallots, err = s.cardinalBallotRepo.GetByElectionID(electionEntity.ElectionID, electionEntity.BallotType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s ballots: %v", electionEntity.BallotType, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid method: %s ballots cannot be tabulated", electionEntity.BallotType)
+	}
+
+	return input, nil
+}
@@ -0,0 +1,385 @@
+package application
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/trustee"
+)
+
+// TrusteeService runs the key generation ceremony of an election's trustees and collects
+// their partial decryptions of the election's aggregate ciphertext.
+// Shares a dealer sends the other trustees never pass through the service; only the
+// dealer's commitments, complaints and the shares revealed to answer them are public.
+type TrusteeService struct {
+	trusteeRepo         trustee.Repository
+	electionRepo        election.Repository
+	encryptedBallotRepo ballot.EncryptedBallotRepository
+	electionKeyRepo     ballot.ElectionKeyRepository
+}
+
+// NewTrusteeService creates a new trustee service
+func NewTrusteeService(
+	trusteeRepo trustee.Repository,
+	electionRepo election.Repository,
+	encryptedBallotRepo ballot.EncryptedBallotRepository,
+	electionKeyRepo ballot.ElectionKeyRepository,
+) *TrusteeService {
+	return &TrusteeService{
+		trusteeRepo:         trusteeRepo,
+		electionRepo:        electionRepo,
+		encryptedBallotRepo: encryptedBallotRepo,
+		electionKeyRepo:     electionKeyRepo,
+	}
+}
+
+// CreateCeremony starts the key generation ceremony of an encrypted election that has no key yet.
+// Trustees are numbered from 1 in the order given.
+func (s *TrusteeService) CreateCeremony(electionID string, req trustee.CeremonyRequest) (*trustee.Ceremony, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	if _, err := s.getKeyGenerationElection(electionID); err != nil {
+		return nil, err
+	}
+
+	key, err := s.electionKeyRepo.GetByElectionID(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		return nil, fmt.Errorf("election %s already has a key", electionID)
+	}
+
+	ceremony := &trustee.Ceremony{
+		ElectionID: electionID,
+		Threshold:  req.Threshold,
+		Trustees:   make([]*trustee.Trustee, len(req.Trustees)),
+		Complaints: []*trustee.Complaint{},
+	}
+	for i, t := range req.Trustees {
+		ceremony.Trustees[i] = &trustee.Trustee{
+			ElectionID:  electionID,
+			Index:       i + 1,
+			Name:        t.Name,
+			Commitments: []string{},
+		}
+	}
+
+	if err := s.trusteeRepo.CreateCeremony(ceremony); err != nil {
+		return nil, err
+	}
+
+	return ceremony, nil
+}
+
+// GetCeremony retrieves the key generation ceremony of an election
+func (s *TrusteeService) GetCeremony(electionID string) (*trustee.Ceremony, error) {
+	if electionID == "" {
+		return nil, fmt.Errorf("election_id is required")
+	}
+
+	return s.getCeremony(electionID)
+}
+
+// SubmitCommitments records a dealer's Feldman commitments, one per coefficient of its polynomial.
+// The dealer must prove it knows the constant term, so it cannot choose C_0 from the other dealers'
+// commitments to control the election key.
+func (s *TrusteeService) SubmitCommitments(electionID string, index int, req trustee.CommitmentsRequest) (*trustee.Trustee, error) {
+	ceremony, err := s.getOpenCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	dealer, err := ceremony.Trustee(index)
+	if err != nil {
+		return nil, err
+	}
+	if dealer.CommittedAt != nil {
+		return nil, fmt.Errorf("trustee %d of election %s has already committed", index, electionID)
+	}
+
+	if len(req.Commitments) != ceremony.Threshold {
+		return nil, fmt.Errorf("validation failed: commitments must have %d entries, one per coefficient", ceremony.Threshold)
+	}
+	commitments, err := trustee.ParseElements(req.Commitments, "commitment")
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+	if req.CommitmentProof == nil {
+		return nil, fmt.Errorf("validation failed: commitment_proof is required")
+	}
+	if !trustee.VerifyConstantTerm(electionID, index, commitments, *req.CommitmentProof) {
+		return nil, fmt.Errorf("validation failed: commitment_proof does not prove knowledge of the constant term of commitment 1")
+	}
+
+	dealer.Commitments = req.Commitments
+	dealer.CommitmentProof = req.CommitmentProof
+	if err := s.trusteeRepo.SaveCommitments(dealer); err != nil {
+		return nil, err
+	}
+
+	return dealer, nil
+}
+
+// FileComplaint records that the share a dealer sent a trustee does not match the dealer's commitments
+func (s *TrusteeService) FileComplaint(electionID string, complainer int, req trustee.ComplaintRequest) (*trustee.Complaint, error) {
+	ceremony, err := s.getOpenCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ceremony.Trustee(complainer); err != nil {
+		return nil, err
+	}
+	dealer, err := ceremony.Trustee(req.Dealer)
+	if err != nil {
+		return nil, err
+	}
+	if dealer.Index == complainer {
+		return nil, fmt.Errorf("validation failed: a trustee cannot complain about itself")
+	}
+	if dealer.CommittedAt == nil {
+		return nil, fmt.Errorf("validation failed: trustee %d has not committed", dealer.Index)
+	}
+
+	complaint := &trustee.Complaint{
+		ElectionID: electionID,
+		Dealer:     dealer.Index,
+		Complainer: complainer,
+	}
+	if err := s.trusteeRepo.CreateComplaint(complaint); err != nil {
+		return nil, err
+	}
+
+	return complaint, nil
+}
+
+// RevealShare answers a complaint with the share the dealer sent the complainer. A share that
+// does not match the dealer's commitments disqualifies the dealer; a valid one becomes the
+// complainer's share from that dealer.
+func (s *TrusteeService) RevealShare(electionID string, dealerIndex int, req trustee.RevealRequest) (*trustee.Complaint, error) {
+	ceremony, err := s.getOpenCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	dealer, err := ceremony.Trustee(dealerIndex)
+	if err != nil {
+		return nil, err
+	}
+	complaint := ceremony.Complaint(dealer.Index, req.Recipient)
+	if complaint == nil {
+		return nil, fmt.Errorf("complaint of trustee %d about trustee %d was not found", req.Recipient, dealer.Index)
+	}
+	if complaint.ResolvedAt != nil {
+		return nil, fmt.Errorf("complaint of trustee %d about trustee %d is already resolved", req.Recipient, dealer.Index)
+	}
+
+	share, err := trustee.ParseShare(req.Share)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+	commitments, err := dealer.ParseCommitments()
+	if err != nil {
+		return nil, err
+	}
+
+	complaint.RevealedShare = req.Share
+	if err := s.trusteeRepo.ResolveComplaint(complaint, !trustee.VerifyShare(commitments, req.Recipient, share)); err != nil {
+		return nil, err
+	}
+
+	return complaint, nil
+}
+
+// FinalizeCeremony derives the election key from the qualified dealers' commitments, together with
+// every trustee's verification key. Dealers with an unanswered complaint are disqualified, and at least
+// threshold dealers must qualify.
+func (s *TrusteeService) FinalizeCeremony(electionID string) (*ballot.ElectionKey, error) {
+	if _, err := s.getKeyGenerationElection(electionID); err != nil {
+		return nil, err
+	}
+
+	ceremony, err := s.getOpenCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	qualified := ceremony.Qualified()
+	if len(qualified) < ceremony.Threshold {
+		return nil, fmt.Errorf("validation failed: %d trustees qualified, at least %d are required", len(qualified), ceremony.Threshold)
+	}
+
+	isQualified := make(map[int]bool, len(qualified))
+	dealerCommitments := make([][]*big.Int, len(qualified))
+	for i, dealer := range qualified {
+		isQualified[dealer.Index] = true
+		if dealerCommitments[i], err = dealer.ParseCommitments(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, t := range ceremony.Trustees {
+		t.Disqualified = !isQualified[t.Index]
+		t.VerificationKey = trustee.EncodeElement(trustee.VerificationKey(dealerCommitments, t.Index))
+	}
+
+	key := &ballot.ElectionKey{
+		ElectionID: electionID,
+		Group:      ballot.ElGamalGroupName,
+		PublicKey:  trustee.EncodeElement(trustee.JointPublicKey(dealerCommitments)),
+		Threshold:  ceremony.Threshold,
+	}
+	if err := s.trusteeRepo.Finalize(ceremony, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetAggregate returns the aggregate ciphertext of a closed election that trustees decrypt
+func (s *TrusteeService) GetAggregate(electionID string) (*ballot.EncryptedAggregate, error) {
+	electionEntity, err := s.getDecryptionElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate, counted, err := s.aggregate(electionEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ballot.EncryptedAggregate{
+		ElectionID: electionID,
+		Ballots:    counted,
+		Aggregate:  base64.StdEncoding.EncodeToString(aggregate.Bytes()),
+	}, nil
+}
+
+// SubmitDecryptionShare records a trustee's partial decryption of the aggregate ciphertext
+// after verifying each factor's proof against the trustee's verification key
+func (s *TrusteeService) SubmitDecryptionShare(electionID string, index int, req trustee.DecryptionShareRequest) (*trustee.DecryptionShare, error) {
+	electionEntity, err := s.getDecryptionElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremony, err := s.getCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if !ceremony.IsFinalized() {
+		return nil, fmt.Errorf("validation failed: key generation for election %s is not finalized", electionID)
+	}
+	t, err := ceremony.Trustee(index)
+	if err != nil {
+		return nil, err
+	}
+
+	share := &trustee.DecryptionShare{
+		ElectionID:   electionID,
+		TrusteeIndex: index,
+		Factors:      req.Factors,
+		Proofs:       req.Proofs,
+	}
+	decryption, err := share.ToPartialDecryption()
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+	verificationKey, err := t.ParseVerificationKey()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate, _, err := s.aggregate(electionEntity)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryption.Verify(electionID, verificationKey, aggregate); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	if err := s.trusteeRepo.CreateDecryptionShare(share); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// aggregate multiplies the accepted ballots of an election
+func (s *TrusteeService) aggregate(electionEntity *election.Election) (ballot.BallotCiphertext, int, error) {
+	ballots, err := s.encryptedBallotRepo.GetByElectionID(electionEntity.ElectionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get encrypted ballots: %v", err)
+	}
+	return ballot.AggregateBallots(ballots, len(electionEntity.CandidateIDs))
+}
+
+// getKeyGenerationElection loads an encrypted election whose key can still be generated
+func (s *TrusteeService) getKeyGenerationElection(electionID string) (*election.Election, error) {
+	electionEntity, err := s.getEncryptedElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if electionEntity.Phase != election.PhaseDraft && electionEntity.Phase != election.PhaseRegistration {
+		return nil, fmt.Errorf("election keys cannot be created for election %s in phase %s", electionID, electionEntity.Phase)
+	}
+	return electionEntity, nil
+}
+
+// getDecryptionElection loads an encrypted election whose results may be published
+func (s *TrusteeService) getDecryptionElection(electionID string) (*election.Election, error) {
+	electionEntity, err := s.getEncryptedElection(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := electionEntity.AllowsResults(); err != nil {
+		return nil, err
+	}
+	return electionEntity, nil
+}
+
+// getEncryptedElection loads an election that accepts encrypted ballots
+func (s *TrusteeService) getEncryptedElection(electionID string) (*election.Election, error) {
+	if electionID == "" {
+		return nil, fmt.Errorf("election_id is required")
+	}
+
+	electionEntity, err := s.electionRepo.GetByID(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if electionEntity.BallotType != election.BallotTypeEncrypted {
+		return nil, fmt.Errorf("invalid election: election %s does not accept %s ballots", electionID, election.BallotTypeEncrypted)
+	}
+	return electionEntity, nil
+}
+
+// getCeremony loads the key generation ceremony of an election, which must have one
+func (s *TrusteeService) getCeremony(electionID string) (*trustee.Ceremony, error) {
+	ceremony, err := s.trusteeRepo.GetCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil {
+		return nil, fmt.Errorf("trustee ceremony for election %s was not found", electionID)
+	}
+	return ceremony, nil
+}
+
+// getOpenCeremony loads a key generation ceremony that is not finalized
+func (s *TrusteeService) getOpenCeremony(electionID string) (*trustee.Ceremony, error) {
+	ceremony, err := s.getCeremony(electionID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony.IsFinalized() {
+		return nil, fmt.Errorf("key generation for election %s is already finalized", electionID)
+	}
+	return ceremony, nil
+}
@@ -4,21 +4,24 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/vote"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
 // VoteService implements the vote.Service interface
 type VoteService struct {
-	voteRepo  vote.Repository
-	voterRepo voter.Repository
+	voteRepo      vote.Repository
+	voterRepo     voter.Repository
+	candidateRepo candidate.Repository
 }
 
 // NewVoteService creates a new vote service
-func NewVoteService(voteRepo vote.Repository, voterRepo voter.Repository) vote.Service {
+func NewVoteService(voteRepo vote.Repository, voterRepo voter.Repository, candidateRepo candidate.Repository) vote.Service {
 	return &VoteService{
-		voteRepo:  voteRepo,
-		voterRepo: voterRepo,
+		voteRepo:      voteRepo,
+		voterRepo:     voterRepo,
+		candidateRepo: candidateRepo,
 	}
 }
 
@@ -51,7 +54,7 @@ func (s *VoteService) CastWeightedVote(req vote.WeightedVoteRequest) (*vote.Weig
 		return nil, fmt.Errorf("error checking if voter has voted: %w", err)
 	}
 	if hasVoted {
-		return nil, fmt.Errorf("voter with id: %d has already voted", req.VoterID)
+		return nil, &voter.AlreadyVotedError{VoterID: req.VoterID}
 	}
 
 	// Get voter information to determine weight based on profile update status
@@ -60,6 +63,11 @@ func (s *VoteService) CastWeightedVote(req vote.WeightedVoteRequest) (*vote.Weig
 		return nil, fmt.Errorf("voter with id: %d was not found", req.VoterID)
 	}
 
+	// Verify candidate exists
+	if err := validateCandidatesExist(s.candidateRepo, []int{req.CandidateID}); err != nil {
+		return nil, err
+	}
+
 	// Determine weight based on profile update status
 	// If voter has recent activity (updated_at different from created_at), give weight 2
 	// Otherwise, give weight 1
@@ -78,7 +86,7 @@ func (s *VoteService) CastWeightedVote(req vote.WeightedVoteRequest) (*vote.Weig
 		UpdatedAt:   now,
 	}
 
-	// Save the vote
+	// Save the vote; the repository marks the voter as having voted in the same transaction
 	err = s.voteRepo.CreateWeightedVote(v)
 	if err != nil {
 		return nil, fmt.Errorf("failed to cast weighted vote: %w", err)
@@ -90,13 +98,6 @@ func (s *VoteService) CastWeightedVote(req vote.WeightedVoteRequest) (*vote.Weig
 		return nil, fmt.Errorf("failed to retrieve created vote: %w", err)
 	}
 
-	// Update voter's has_voted status
-	voterInfo.HasVoted = true
-	err = s.voterRepo.Update(voterInfo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update voter status: %w", err)
-	}
-
 	return &vote.WeightedVoteResponse{
 		VoteID:      storedVote.VoteID,
 		VoterID:     storedVote.VoterID,
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+)
+
+// VoterKeyService implements the voter.KeyService interface
+type VoterKeyService struct {
+	keyRepo      voter.KeyRepository
+	voterRepo    voter.Repository
+	electionRepo election.Repository
+}
+
+// NewVoterKeyService creates a new voter key service
+func NewVoterKeyService(keyRepo voter.KeyRepository, voterRepo voter.Repository, electionRepo election.Repository) voter.KeyService {
+	return &VoterKeyService{
+		keyRepo:      keyRepo,
+		voterRepo:    voterRepo,
+		electionRepo: electionRepo,
+	}
+}
+
+// EnrollKey enrolls an Ed25519 public key for a voter in an encrypted ballot election.
+// Keys are enrolled before voting opens, while the election is in draft or registration.
+func (s *VoterKeyService) EnrollKey(voterID int, req voter.KeyRequest) (*voter.Key, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+	if _, err := ballot.ParseEd25519PublicKey(req.PublicKey); err != nil {
+		return nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	if _, err := s.voterRepo.GetByID(voterID); err != nil {
+		return nil, err
+	}
+
+	electionEntity, err := s.electionRepo.GetByID(req.ElectionID)
+	if err != nil {
+		return nil, err
+	}
+	if electionEntity.BallotType != election.BallotTypeEncrypted {
+		return nil, fmt.Errorf("invalid election: election %s does not accept %s ballots", req.ElectionID, election.BallotTypeEncrypted)
+	}
+	if electionEntity.Phase != election.PhaseDraft && electionEntity.Phase != election.PhaseRegistration {
+		return nil, fmt.Errorf("voter keys cannot be enrolled in election %s in phase %s", req.ElectionID, electionEntity.Phase)
+	}
+
+	key := &voter.Key{
+		VoterID:    voterID,
+		ElectionID: req.ElectionID,
+		PublicKey:  req.PublicKey,
+	}
+	if err := s.keyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetVoterKeys lists the keys a voter enrolled, including revoked keys.
+// An empty election ID lists the voter's keys in every election.
+func (s *VoterKeyService) GetVoterKeys(voterID int, electionID string) (*voter.KeysResponse, error) {
+	if _, err := s.voterRepo.GetByID(voterID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keyRepo.GetByVoterID(voterID, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &voter.KeysResponse{
+		VoterID: voterID,
+		Keys:    keys,
+	}, nil
+}
+
+// RevokeKey revokes one of a voter's keys so it no longer signs ballots.
+// Ballots already cast with the key are kept. A key can be revoked until its election closes.
+func (s *VoterKeyService) RevokeKey(voterID, keyID int) (*voter.Key, error) {
+	key, err := s.keyRepo.GetByID(keyID)
+	if err != nil {
+		return nil, err
+	}
+	// Another voter's key is reported as missing rather than revealing who enrolled it
+	if key.VoterID != voterID {
+		return nil, fmt.Errorf("voter key with id: %d was not found", keyID)
+	}
+	if key.IsRevoked() {
+		return nil, fmt.Errorf("voter key with id: %d is already revoked", keyID)
+	}
+
+	electionEntity, err := s.electionRepo.GetByID(key.ElectionID)
+	if err != nil {
+		return nil, err
+	}
+	switch electionEntity.Phase {
+	case election.PhaseDraft, election.PhaseRegistration, election.PhaseVoting:
+	default:
+		return nil, fmt.Errorf("voter keys cannot be revoked in election %s in phase %s", key.ElectionID, electionEntity.Phase)
+	}
+
+	if err := s.keyRepo.Revoke(key, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
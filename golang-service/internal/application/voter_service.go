@@ -3,17 +3,22 @@ package application
 import (
 	"fmt"
 
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
 // VoterService implements the voter.Service interface
 type VoterService struct {
-	repo voter.Repository
+	repo         voter.Repository
+	electionRepo election.Repository
 }
 
 // NewVoterService creates a new voter service
-func NewVoterService(repo voter.Repository) voter.Service {
-	return &VoterService{repo: repo}
+func NewVoterService(repo voter.Repository, electionRepo election.Repository) voter.Service {
+	return &VoterService{
+		repo:         repo,
+		electionRepo: electionRepo,
+	}
 }
 
 // CreateVoter creates a new voter with validation
@@ -99,6 +104,11 @@ func (s *VoterService) UpdateVoter(voterID int, req voter.VoterRequest) (*voter.
 		return nil, err
 	}
 
+	// The voter roll is frozen while any election is between voting and certification
+	if err := s.checkVoterRollOpen(); err != nil {
+		return nil, err
+	}
+
 	// Create updated voter model
 	updatedVoter := &voter.Voter{
 		VoterID:   voterID,
@@ -131,3 +141,19 @@ func (s *VoterService) UpdateVoter(voterID int, req voter.VoterRequest) (*voter.
 func (s *VoterService) DeleteVoter(voterID int) error {
 	return s.repo.Delete(voterID)
 }
+
+// checkVoterRollOpen returns an error if any election currently freezes the voter roll
+func (s *VoterService) checkVoterRollOpen() error {
+	elections, err := s.electionRepo.GetByPhase(election.PhaseVoting, election.PhaseClosed, election.PhaseTallied)
+	if err != nil {
+		return fmt.Errorf("error checking election phases: %w", err)
+	}
+
+	for _, e := range elections {
+		if e.FreezesVoterRoll() {
+			return fmt.Errorf("voter edits are not allowed while election %s is in phase %s", e.ElectionID, e.Phase)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package ballot
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+// BallotProofContext prefixes the context of every ballot well-formedness proof
+const BallotProofContext = "saracen-voting/ballot-proof/v1"
+
+// BallotProof proves that a ballot ciphertext is well formed without revealing its votes:
+// each candidate's ciphertext encrypts 0 or 1, and their sum is between 0 and the
+// election's max selections. Encoded, it is every branch's challenge then response as
+// ElementSize-byte big-endian integers, first the two branches of each candidate in
+// ballot order, then the max selections + 1 branches of the sum.
+type BallotProof struct {
+	Selections []DisjunctiveProof
+	Sum        DisjunctiveProof
+}
+
+// ProveBallot proves that a ballot ciphertext, encrypted with the given nonces, is well formed.
+// The proof is bound to the election and the voter key that signs the ballot.
+func ProveBallot(electionID string, voterPubkey []byte, publicKey *big.Int, ciphertext BallotCiphertext, votes []int, nonces []*big.Int, maxSelections int, random io.Reader) (*BallotProof, error) {
+	g := ElGamalGroup
+	if len(votes) != len(ciphertext) || len(nonces) != len(ciphertext) {
+		return nil, fmt.Errorf("votes and nonces must have one entry per candidate")
+	}
+
+	proof := &BallotProof{Selections: make([]DisjunctiveProof, len(ciphertext))}
+	selections := 0
+	nonceSum := new(big.Int)
+	for i, c := range ciphertext {
+		var err error
+		proof.Selections[i], err = g.ProveEncryptsOneOf(ballotProofContext(electionID, voterPubkey, strconv.Itoa(i)), publicKey, c, votes[i], 0, 1, nonces[i], random)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %d: %v", i+1, err)
+		}
+		selections += votes[i]
+		nonceSum.Add(nonceSum, nonces[i])
+	}
+
+	var err error
+	proof.Sum, err = g.ProveEncryptsOneOf(ballotProofContext(electionID, voterPubkey, "sum"), publicKey, ciphertext.Sum(), selections, 0, maxSelections, nonceSum.Mod(nonceSum, g.Q), random)
+	if err != nil {
+		return nil, fmt.Errorf("selections: %v", err)
+	}
+
+	return proof, nil
+}
+
+// VerifyBallotProof verifies that a ballot ciphertext is well formed for an election
+// allowing maxSelections selections
+func VerifyBallotProof(electionID string, voterPubkey []byte, publicKey *big.Int, ciphertext BallotCiphertext, proof *BallotProof, maxSelections int) error {
+	g := ElGamalGroup
+	if len(proof.Selections) != len(ciphertext) {
+		return fmt.Errorf("invalid zk_proof: must have one selection proof per candidate")
+	}
+
+	for i, c := range ciphertext {
+		if !g.VerifyEncryptsOneOf(ballotProofContext(electionID, voterPubkey, strconv.Itoa(i)), publicKey, c, 0, 1, proof.Selections[i]) {
+			return fmt.Errorf("invalid zk_proof: candidate %d does not encrypt 0 or 1", i+1)
+		}
+	}
+
+	if !g.VerifyEncryptsOneOf(ballotProofContext(electionID, voterPubkey, "sum"), publicKey, ciphertext.Sum(), 0, maxSelections, proof.Sum) {
+		return fmt.Errorf("invalid zk_proof: ballot does not select at most %d candidates", maxSelections)
+	}
+
+	return nil
+}
+
+// ParseBallotProof decodes an encoded ballot proof for an election with the given number of
+// candidates and max selections
+func ParseBallotProof(data []byte, candidates, maxSelections int) (*BallotProof, error) {
+	g := ElGamalGroup
+	branchSize := 2 * g.ElementSize
+	if want := branchSize * (2*candidates + maxSelections + 1); len(data) != want {
+		return nil, fmt.Errorf("invalid zk_proof: must be %d bytes for %d candidates, got %d", want, candidates, len(data))
+	}
+
+	proof := &BallotProof{Selections: make([]DisjunctiveProof, candidates)}
+	branches := func(n int) DisjunctiveProof {
+		branches := make(DisjunctiveProof, n)
+		for j := range branches {
+			branches[j] = ChaumPedersenProof{
+				Challenge: new(big.Int).SetBytes(data[:g.ElementSize]),
+				Response:  new(big.Int).SetBytes(data[g.ElementSize:branchSize]),
+			}
+			data = data[branchSize:]
+		}
+		return branches
+	}
+	for i := range proof.Selections {
+		proof.Selections[i] = branches(2)
+	}
+	proof.Sum = branches(maxSelections + 1)
+
+	return proof, nil
+}
+
+// DecodeBallotProof decodes a base64 ballot proof
+func DecodeBallotProof(zkProof string, candidates, maxSelections int) (*BallotProof, error) {
+	data, err := base64.StdEncoding.DecodeString(zkProof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zk_proof: must be valid base64: %v", err)
+	}
+	return ParseBallotProof(data, candidates, maxSelections)
+}
+
+// Bytes encodes the ballot proof
+func (p *BallotProof) Bytes() []byte {
+	g := ElGamalGroup
+	var data []byte
+	for _, proof := range append(append([]DisjunctiveProof{}, p.Selections...), p.Sum) {
+		for _, branch := range proof {
+			data = append(data, g.EncodeElement(branch.Challenge)...)
+			data = append(data, g.EncodeElement(branch.Response)...)
+		}
+	}
+	return data
+}
+
+// Sum returns the ciphertext of the number of candidates the ballot selects
+func (c BallotCiphertext) Sum() ElGamalCiphertext {
+	sum := ElGamalCiphertext{Alpha: big.NewInt(1), Beta: big.NewInt(1)}
+	for _, candidate := range c {
+		sum = ElGamalGroup.Multiply(sum, candidate)
+	}
+	return sum
+}
+
+// ballotProofContext binds a ballot proof to the election, the voter key and the proof's position,
+// a candidate's zero-based position or "sum"
+func ballotProofContext(electionID string, voterPubkey []byte, position string) []byte {
+	return ProofContext(BallotProofContext, []byte(electionID), voterPubkey, []byte(position))
+}
@@ -0,0 +1,112 @@
+package ballot
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyBallotProof(t *testing.T) {
+	const electionID = "proof-test"
+	g := ElGamalGroup
+	secret, err := g.RandomExponent(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := g.Exp(secret)
+	voterPubkey := []byte("voter-key-1")
+
+	tests := []struct {
+		name          string
+		votes         []int
+		maxSelections int
+		// tamper changes the ballot after it is proven; the nonces are those it was encrypted with
+		tamper func(ciphertext BallotCiphertext, nonces []*big.Int)
+		// electionID, voterPubkey and maxSelections the ballot is verified for, if not those it was proven for
+		verifyElectionID    string
+		verifyVoterPubkey   []byte
+		verifyMaxSelections int
+		wantErr             bool
+	}{
+		{
+			name:          "single selection",
+			votes:         []int{0, 1, 0},
+			maxSelections: 1,
+		},
+		{
+			name:          "blank ballot",
+			votes:         []int{0, 0, 0},
+			maxSelections: 1,
+		},
+		{
+			name:          "several selections within max selections",
+			votes:         []int{1, 0, 1},
+			maxSelections: 2,
+		},
+		{
+			name:          "candidate ciphertext encrypting 2",
+			votes:         []int{1, 0, 0},
+			maxSelections: 2,
+			tamper: func(ciphertext BallotCiphertext, nonces []*big.Int) {
+				ciphertext[0] = g.Encrypt(publicKey, 2, nonces[0])
+			},
+			wantErr: true,
+		},
+		{
+			name:                "more selections than max selections",
+			votes:               []int{1, 1, 0},
+			maxSelections:       2,
+			verifyMaxSelections: 1,
+			wantErr:             true,
+		},
+		{
+			name:             "proof bound to another election",
+			votes:            []int{0, 1, 0},
+			maxSelections:    1,
+			verifyElectionID: "other-election",
+			wantErr:          true,
+		},
+		{
+			name:              "proof bound to another voter key",
+			votes:             []int{0, 1, 0},
+			maxSelections:     1,
+			verifyVoterPubkey: []byte("voter-key-2"),
+			wantErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ciphertext, nonces, err := EncryptBallot(publicKey, tt.votes, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := ProveBallot(electionID, voterPubkey, publicKey, ciphertext, tt.votes, nonces, tt.maxSelections, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(ciphertext, nonces)
+			}
+
+			verifyElectionID, verifyVoterPubkey, verifyMaxSelections := electionID, voterPubkey, tt.maxSelections
+			if tt.verifyElectionID != "" {
+				verifyElectionID = tt.verifyElectionID
+			}
+			if tt.verifyVoterPubkey != nil {
+				verifyVoterPubkey = tt.verifyVoterPubkey
+			}
+			if tt.verifyMaxSelections != 0 {
+				verifyMaxSelections = tt.verifyMaxSelections
+			}
+
+			err = VerifyBallotProof(verifyElectionID, verifyVoterPubkey, publicKey, ciphertext, proof, verifyMaxSelections)
+			if tt.wantErr && err == nil {
+				t.Fatal("invalid ballot accepted")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("valid ballot rejected: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package ballot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Borda point schemes
+const (
+	BordaStandard = "standard"
+	BordaDowdall  = "dowdall"
+	BordaModified = "modified"
+)
+
+// BordaResult represents the result of a Borda count
+type BordaResult struct {
+	ElectionID   string       `json:"election_id"`
+	Method       string       `json:"method"`
+	Scheme       string       `json:"scheme"`
+	Winners      []int        `json:"winners"`
+	TotalBallots int          `json:"total_ballots"`
+	Scores       []BordaScore `json:"scores"`
+}
+
+// BordaScore represents a candidate's total Borda points
+type BordaScore struct {
+	CandidateID int     `json:"candidate_id"`
+	Points      float64 `json:"points"`
+}
+
+// ValidateBordaScheme validates a Borda point scheme name, defaulting to standard
+func ValidateBordaScheme(scheme string) (string, error) {
+	switch scheme {
+	case "":
+		return BordaStandard, nil
+	case BordaStandard, BordaDowdall, BordaModified:
+		return scheme, nil
+	}
+	return "", fmt.Errorf("invalid borda scheme: %s", scheme)
+}
+
+// CalculateBorda implements the Borda count.
+//
+// With n candidates ranked on any ballot, the candidate in position r of a ballot scores
+// n-r points under the standard scheme and 1/r under Dowdall. The modified scheme suits
+// truncated ballots: with k candidates ranked on a ballot, position r scores k-r+1, so a
+// voter ranking fewer candidates gives fewer points. Unranked candidates score 0 under
+// every scheme, and equally ranked candidates share the average of the positions they span.
+func CalculateBorda(ballots []RankedBallotWithRankings, scheme string) (*BordaResult, error) {
+	scheme, err := ValidateBordaScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BordaResult{
+		Method:       "borda",
+		Scheme:       scheme,
+		Winners:      []int{},
+		TotalBallots: len(ballots),
+		Scores:       []BordaScore{},
+	}
+
+	preferences, candidates := orderedPreferences(ballots)
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	points := make(map[int]float64)
+	for _, candidate := range candidates {
+		points[candidate] = 0
+	}
+
+	for _, prefs := range preferences {
+		ranked := 0
+		for _, tier := range prefs {
+			ranked += len(tier)
+		}
+
+		position := 1
+		for _, tier := range prefs {
+			// Average the points of every position the tier spans
+			share := 0.0
+			for r := position; r < position+len(tier); r++ {
+				share += bordaPoints(scheme, r, len(candidates), ranked)
+			}
+			share /= float64(len(tier))
+
+			for _, candidate := range tier {
+				points[candidate] += share
+			}
+			position += len(tier)
+		}
+	}
+
+	for _, candidate := range candidates {
+		result.Scores = append(result.Scores, BordaScore{CandidateID: candidate, Points: roundVotes(points[candidate])})
+	}
+	sort.SliceStable(result.Scores, func(i, j int) bool {
+		return result.Scores[i].Points > result.Scores[j].Points
+	})
+
+	for _, score := range result.Scores {
+		if score.Points == result.Scores[0].Points {
+			result.Winners = append(result.Winners, score.CandidateID)
+		}
+	}
+
+	return result, nil
+}
+
+// bordaPoints returns the points for position r (1-based) of a ballot ranking the given number of candidates
+func bordaPoints(scheme string, r, candidates, ranked int) float64 {
+	switch scheme {
+	case BordaDowdall:
+		return 1 / float64(r)
+	case BordaModified:
+		return float64(ranked - r + 1)
+	default:
+		return float64(candidates - r)
+	}
+}
@@ -0,0 +1,261 @@
+package ballot
+
+import (
+	"math"
+	"sort"
+)
+
+// ApprovalResult represents the result of an approval tabulation
+type ApprovalResult struct {
+	ElectionID   string          `json:"election_id"`
+	Method       string          `json:"method"`
+	Winners      []int           `json:"winners"`
+	TotalBallots int             `json:"total_ballots"`
+	Tallies      []CardinalTally `json:"tallies"`
+}
+
+// ScoreResult represents the result of a range/score tabulation
+type ScoreResult struct {
+	ElectionID   string          `json:"election_id"`
+	Method       string          `json:"method"`
+	MaxScore     int             `json:"max_score"`
+	Winners      []int           `json:"winners"`
+	TotalBallots int             `json:"total_ballots"`
+	Tallies      []CardinalTally `json:"tallies"`
+}
+
+// CardinalTally represents a candidate's total and average score.
+// For approval ballots the total is the number of approvals and the average the approval rate.
+type CardinalTally struct {
+	CandidateID int     `json:"candidate_id"`
+	Total       int     `json:"total"`
+	Average     float64 `json:"average"`
+}
+
+// STARResult represents the result of a STAR (Score Then Automatic Runoff) tabulation
+type STARResult struct {
+	ElectionID   string          `json:"election_id"`
+	Method       string          `json:"method"`
+	MaxScore     int             `json:"max_score"`
+	Winner       int             `json:"winner,omitempty"`
+	TotalBallots int             `json:"total_ballots"`
+	ScoringRound []CardinalTally `json:"scoring_round"`
+	Finalists    []int           `json:"finalists"`
+	Runoff       *STARRunoff     `json:"runoff,omitempty"`
+	TieBreaks    []string        `json:"tie_breaks,omitempty"`
+}
+
+// STARRunoff represents the automatic runoff between the two STAR finalists
+type STARRunoff struct {
+	Preferences  []STARPreference `json:"preferences"`
+	NoPreference int              `json:"no_preference"`
+}
+
+// STARPreference represents the ballots preferring a finalist in the runoff
+type STARPreference struct {
+	CandidateID int `json:"candidate_id"`
+	Votes       int `json:"votes"`
+}
+
+// CalculateApproval implements approval voting: the candidates approved on the most ballots win
+func CalculateApproval(ballots []CardinalBallotWithScores) *ApprovalResult {
+	tallies, _ := cardinalTallies(ballots)
+	return &ApprovalResult{
+		Method:       "approval",
+		Winners:      topCardinal(tallies),
+		TotalBallots: len(ballots),
+		Tallies:      tallies,
+	}
+}
+
+// CalculateScore implements range/score voting: the candidates with the highest total score win.
+// A candidate a ballot does not score counts as a score of 0 on that ballot.
+func CalculateScore(ballots []CardinalBallotWithScores, maxScore int) *ScoreResult {
+	tallies, _ := cardinalTallies(ballots)
+	return &ScoreResult{
+		Method:       "score",
+		MaxScore:     maxScore,
+		Winners:      topCardinal(tallies),
+		TotalBallots: len(ballots),
+		Tallies:      tallies,
+	}
+}
+
+// CalculateSTAR implements STAR voting (Score Then Automatic Runoff).
+//
+// The two candidates with the highest total scores are finalists, and the finalist
+// scored higher on more ballots wins the runoff. Ties for a finalist place are broken by
+// head-to-head wins among the tied candidates, then by the number of ballots giving the
+// candidate the maximum score, then by the lower candidate ID. A tied runoff goes to the
+// finalist with the higher total score, then to the finalist placed first in the scoring round.
+func CalculateSTAR(ballots []CardinalBallotWithScores, maxScore int) *STARResult {
+	tallies, scoreMaps := cardinalTallies(ballots)
+	result := &STARResult{
+		Method:       "star",
+		MaxScore:     maxScore,
+		TotalBallots: len(ballots),
+		ScoringRound: tallies,
+		Finalists:    []int{},
+	}
+
+	if len(tallies) == 0 {
+		return result
+	}
+
+	order, tieBreaks := starScoringOrder(tallies, scoreMaps, maxScore)
+	result.TieBreaks = tieBreaks
+
+	if len(order) == 1 {
+		result.Finalists = []int{order[0]}
+		result.Winner = order[0]
+		return result
+	}
+
+	first, second := order[0], order[1]
+	result.Finalists = []int{first, second}
+
+	firstVotes, secondVotes := headToHead(scoreMaps, first, second)
+	result.Runoff = &STARRunoff{
+		Preferences: []STARPreference{
+			{CandidateID: first, Votes: firstVotes},
+			{CandidateID: second, Votes: secondVotes},
+		},
+		NoPreference: len(ballots) - firstVotes - secondVotes,
+	}
+
+	switch {
+	case firstVotes > secondVotes:
+		result.Winner = first
+	case secondVotes > firstVotes:
+		result.Winner = second
+	default:
+		// Finalists are already ordered by total score, then by the scoring round tie-breaks
+		result.Winner = first
+		result.TieBreaks = append(result.TieBreaks, "runoff tied; won by the finalist placed first in the scoring round")
+	}
+
+	return result
+}
+
+// cardinalTallies totals every candidate's scores, ordered by total (descending), then candidate ID,
+// and returns each ballot's scores by candidate
+func cardinalTallies(ballots []CardinalBallotWithScores) ([]CardinalTally, []map[int]int) {
+	totals := make(map[int]int)
+	scoreMaps := make([]map[int]int, len(ballots))
+
+	for i, ballot := range ballots {
+		scoreMaps[i] = make(map[int]int)
+		for _, score := range ballot.Scores {
+			totals[score.CandidateID] += score.Score
+			scoreMaps[i][score.CandidateID] = score.Score
+		}
+	}
+
+	tallies := make([]CardinalTally, 0, len(totals))
+	for candidate, total := range totals {
+		tally := CardinalTally{CandidateID: candidate, Total: total}
+		if len(ballots) > 0 {
+			tally.Average = math.Round(float64(total)/float64(len(ballots))*1e6) / 1e6
+		}
+		tallies = append(tallies, tally)
+	}
+
+	sort.Slice(tallies, func(i, j int) bool {
+		if tallies[i].Total != tallies[j].Total {
+			return tallies[i].Total > tallies[j].Total
+		}
+		return tallies[i].CandidateID < tallies[j].CandidateID
+	})
+
+	return tallies, scoreMaps
+}
+
+// topCardinal returns every candidate sharing the highest total of sorted tallies
+func topCardinal(tallies []CardinalTally) []int {
+	winners := []int{}
+	for _, tally := range tallies {
+		if tally.Total != tallies[0].Total {
+			break
+		}
+		winners = append(winners, tally.CandidateID)
+	}
+	return winners
+}
+
+// headToHead counts the ballots scoring a above b and b above a
+func headToHead(scoreMaps []map[int]int, a, b int) (int, int) {
+	aVotes, bVotes := 0, 0
+	for _, scores := range scoreMaps {
+		switch {
+		case scores[a] > scores[b]:
+			aVotes++
+		case scores[b] > scores[a]:
+			bVotes++
+		}
+	}
+	return aVotes, bVotes
+}
+
+// starScoringOrder orders candidates for the STAR scoring round and describes any ties it broke
+func starScoringOrder(tallies []CardinalTally, scoreMaps []map[int]int, maxScore int) ([]int, []string) {
+	totals := make(map[int]int)
+	byTotal := make(map[int][]int)
+	for _, tally := range tallies {
+		totals[tally.CandidateID] = tally.Total
+		byTotal[tally.Total] = append(byTotal[tally.Total], tally.CandidateID)
+	}
+
+	// Head-to-head wins against candidates with the same total
+	groupWins := make(map[int]int)
+	for _, group := range byTotal {
+		for _, a := range group {
+			for _, b := range group {
+				if a == b {
+					continue
+				}
+				aVotes, bVotes := headToHead(scoreMaps, a, b)
+				if aVotes > bVotes {
+					groupWins[a]++
+				}
+			}
+		}
+	}
+
+	maxCounts := make(map[int]int)
+	for _, scores := range scoreMaps {
+		for candidate, score := range scores {
+			if score == maxScore {
+				maxCounts[candidate]++
+			}
+		}
+	}
+
+	order := make([]int, len(tallies))
+	for i, tally := range tallies {
+		order[i] = tally.CandidateID
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if totals[a] != totals[b] {
+			return totals[a] > totals[b]
+		}
+		if groupWins[a] != groupWins[b] {
+			return groupWins[a] > groupWins[b]
+		}
+		if maxCounts[a] != maxCounts[b] {
+			return maxCounts[a] > maxCounts[b]
+		}
+		return a < b
+	})
+
+	// Only ties deciding who reaches the runoff matter
+	tieBreaks := []string{}
+	for i := 0; i < 2 && i+1 < len(order); i++ {
+		if totals[order[i]] == totals[order[i+1]] {
+			tieBreaks = append(tieBreaks, "scoring round tie broken by head-to-head wins, then maximum scores, then candidate ID")
+			break
+		}
+	}
+
+	return order, tieBreaks
+}
@@ -0,0 +1,236 @@
+package ballot
+
+import (
+	"fmt"
+	"time"
+)
+
+// Cardinal ballot types
+const (
+	CardinalTypeApproval = "approval"
+	CardinalTypeScore    = "score"
+)
+
+// CardinalBallot represents an approval or score ballot
+type CardinalBallot struct {
+	BallotID   string    `json:"ballot_id" db:"ballot_id"`
+	ElectionID string    `json:"election_id" db:"election_id"`
+	VoterID    int       `json:"voter_id" db:"voter_id"`
+	BallotType string    `json:"ballot_type" db:"ballot_type"`
+	Timestamp  time.Time `json:"timestamp" db:"timestamp"`
+	Status     string    `json:"status" db:"status"`
+}
+
+// BallotScore represents the score a cardinal ballot gives one candidate.
+// An approval is stored as a score of 1; candidates without a score scored 0.
+type BallotScore struct {
+	ID          int    `json:"id" db:"id"`
+	BallotID    string `json:"ballot_id" db:"ballot_id"`
+	CandidateID int    `json:"candidate_id" db:"candidate_id"`
+	Score       int    `json:"score" db:"score"`
+}
+
+// ApprovalBallotRequest represents the request payload for an approval ballot
+type ApprovalBallotRequest struct {
+	ElectionID string    `json:"election_id" validate:"required"`
+	VoterID    int       `json:"voter_id" validate:"required,min=1"`
+	Approved   []int     `json:"approved"`
+	Timestamp  time.Time `json:"timestamp" validate:"required"`
+}
+
+// ScoreBallotRequest represents the request payload for a score ballot
+type ScoreBallotRequest struct {
+	ElectionID string           `json:"election_id" validate:"required"`
+	VoterID    int              `json:"voter_id" validate:"required,min=1"`
+	Scores     []CandidateScore `json:"scores" validate:"required,min=1"`
+	Timestamp  time.Time        `json:"timestamp" validate:"required"`
+}
+
+// CandidateScore represents the score given to one candidate in a score ballot request
+type CandidateScore struct {
+	CandidateID int `json:"candidate_id"`
+	Score       int `json:"score"`
+}
+
+// CardinalBallotResponse represents the response for a cast cardinal ballot
+type CardinalBallotResponse struct {
+	BallotID string `json:"ballot_id"`
+	Status   string `json:"status"`
+}
+
+// CardinalBallotWithScores combines a cardinal ballot with its scores for calculation
+type CardinalBallotWithScores struct {
+	Ballot CardinalBallot `json:"ballot"`
+	Scores []BallotScore  `json:"scores"`
+}
+
+// Validate validates the approval ballot request.
+// Approving no candidates is a valid, if empty, ballot.
+func (req *ApprovalBallotRequest) Validate() error {
+	if req.ElectionID == "" {
+		return fmt.Errorf("election_id is required")
+	}
+
+	if req.VoterID <= 0 {
+		return fmt.Errorf("voter_id must be positive")
+	}
+
+	candidateSet := make(map[int]bool)
+	for i, candidateID := range req.Approved {
+		if candidateID <= 0 {
+			return fmt.Errorf("candidate_id at position %d must be positive", i)
+		}
+		if candidateSet[candidateID] {
+			return fmt.Errorf("candidate_id %d appears multiple times in approved", candidateID)
+		}
+		candidateSet[candidateID] = true
+	}
+
+	if req.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+
+	return nil
+}
+
+// ToCardinalBallot converts request to domain model. The ballot is assigned its ID when it is stored.
+func (req *ApprovalBallotRequest) ToCardinalBallot() (*CardinalBallot, []BallotScore, error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ballot := newCardinalBallot(req.ElectionID, req.VoterID, CardinalTypeApproval, req.Timestamp)
+
+	scores := make([]BallotScore, len(req.Approved))
+	for i, candidateID := range req.Approved {
+		scores[i] = BallotScore{
+			CandidateID: candidateID,
+			Score:       1,
+		}
+	}
+
+	return ballot, scores, nil
+}
+
+// Validate validates the score ballot request
+func (req *ScoreBallotRequest) Validate() error {
+	if req.ElectionID == "" {
+		return fmt.Errorf("election_id is required")
+	}
+
+	if req.VoterID <= 0 {
+		return fmt.Errorf("voter_id must be positive")
+	}
+
+	if len(req.Scores) == 0 {
+		return fmt.Errorf("scores array cannot be empty")
+	}
+
+	candidateSet := make(map[int]bool)
+	for i, score := range req.Scores {
+		if score.CandidateID <= 0 {
+			return fmt.Errorf("candidate_id at position %d must be positive", i)
+		}
+		if candidateSet[score.CandidateID] {
+			return fmt.Errorf("candidate_id %d appears multiple times in scores", score.CandidateID)
+		}
+		candidateSet[score.CandidateID] = true
+
+		if score.Score < 0 {
+			return fmt.Errorf("score for candidate_id %d must not be negative", score.CandidateID)
+		}
+	}
+
+	if req.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+
+	return nil
+}
+
+// ValidateMaxScore verifies that no score exceeds the election's highest score
+func (req *ScoreBallotRequest) ValidateMaxScore(maxScore int) error {
+	for _, score := range req.Scores {
+		if score.Score > maxScore {
+			return fmt.Errorf("score for candidate_id %d must be between 0 and %d", score.CandidateID, maxScore)
+		}
+	}
+	return nil
+}
+
+// ToCardinalBallot converts request to domain model. The ballot is assigned its ID when it is stored.
+func (req *ScoreBallotRequest) ToCardinalBallot(maxScore int) (*CardinalBallot, []BallotScore, error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := req.ValidateMaxScore(maxScore); err != nil {
+		return nil, nil, err
+	}
+
+	ballot := newCardinalBallot(req.ElectionID, req.VoterID, CardinalTypeScore, req.Timestamp)
+
+	scores := make([]BallotScore, len(req.Scores))
+	for i, score := range req.Scores {
+		scores[i] = BallotScore{
+			CandidateID: score.CandidateID,
+			Score:       score.Score,
+		}
+	}
+
+	return ballot, scores, nil
+}
+
+// Candidates returns the candidates scored by the request
+func (req *ScoreBallotRequest) Candidates() []int {
+	candidates := make([]int, len(req.Scores))
+	for i, score := range req.Scores {
+		candidates[i] = score.CandidateID
+	}
+	return candidates
+}
+
+// ToResponse converts domain model to response
+func (cb *CardinalBallot) ToResponse() *CardinalBallotResponse {
+	return &CardinalBallotResponse{
+		BallotID: cb.BallotID,
+		Status:   cb.Status,
+	}
+}
+
+// IDPrefix returns the prefix of the ballot's ID, which depends on its ballot type
+func (cb *CardinalBallot) IDPrefix() string {
+	if cb.BallotType == CardinalTypeScore {
+		return ScoreBallotIDPrefix
+	}
+	return ApprovalBallotIDPrefix
+}
+
+// AssignID sets the ID of the ballot and of each of its scores
+func (cb *CardinalBallot) AssignID(ballotID string, scores []BallotScore) {
+	cb.BallotID = ballotID
+	for i := range scores {
+		scores[i].BallotID = ballotID
+	}
+}
+
+// newCardinalBallot creates an accepted cardinal ballot
+func newCardinalBallot(electionID string, voterID int, ballotType string, timestamp time.Time) *CardinalBallot {
+	return &CardinalBallot{
+		ElectionID: electionID,
+		VoterID:    voterID,
+		BallotType: ballotType,
+		Timestamp:  timestamp,
+		Status:     BallotStatusAccepted,
+	}
+}
+
+// CardinalBallotRepository defines repository interface for approval and score ballots. Replace stores
+// a ballot of an election that allows revoting and supersedes the voter's earlier ballot in it.
+// GetByElectionID returns only counted ballots.
+type CardinalBallotRepository interface {
+	Create(ballot *CardinalBallot, scores []BallotScore) error
+	Replace(ballot *CardinalBallot, scores []BallotScore) error
+	GetByBallotID(ballotID string) (*CardinalBallot, []BallotScore, error)
+	GetByElectionID(electionID, ballotType string) ([]CardinalBallotWithScores, error)
+	GetByVoterID(voterID int, ballotType string) ([]*CardinalBallot, error)
+}
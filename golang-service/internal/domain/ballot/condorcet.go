@@ -0,0 +1,336 @@
+package ballot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Minimax variants, differing in how the strength of a pairwise defeat is measured
+const (
+	MinimaxWinningVotes       = "winning_votes"
+	MinimaxMargins            = "margins"
+	MinimaxPairwiseOpposition = "pairwise_opposition"
+)
+
+// RankedPairsResult represents the result of the Ranked Pairs (Tideman) method
+type RankedPairsResult struct {
+	ElectionID   string            `json:"election_id"`
+	Method       string            `json:"method"`
+	Winner       int               `json:"winner"`
+	Ranking      []int             `json:"ranking"`
+	LockedEdges  []RankedPairsEdge `json:"locked_edges"`
+	SkippedEdges []RankedPairsEdge `json:"skipped_edges"`
+	Matrix       [][]int           `json:"pairwise_matrix,omitempty"`
+}
+
+// RankedPairsEdge represents a pairwise victory considered by Ranked Pairs
+type RankedPairsEdge struct {
+	Winner  int `json:"winner"`
+	Loser   int `json:"loser"`
+	For     int `json:"votes_for"`
+	Against int `json:"votes_against"`
+}
+
+// MinimaxResult represents the result of the Minimax method
+type MinimaxResult struct {
+	ElectionID string         `json:"election_id"`
+	Method     string         `json:"method"`
+	Variant    string         `json:"variant"`
+	Winners    []int          `json:"winners"`
+	Scores     []MinimaxScore `json:"scores"`
+	Matrix     [][]int        `json:"pairwise_matrix,omitempty"`
+}
+
+// CopelandResult represents the result of the Copeland method
+type CopelandResult struct {
+	ElectionID string          `json:"election_id"`
+	Method     string          `json:"method"`
+	TieScore   float64         `json:"tie_score"`
+	Winners    []int           `json:"winners"`
+	Scores     []CopelandScore `json:"scores"`
+	Matrix     [][]int         `json:"pairwise_matrix,omitempty"`
+}
+
+// CopelandScore represents a candidate's pairwise record under Copeland
+type CopelandScore struct {
+	CandidateID int     `json:"candidate_id"`
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	Ties        int     `json:"ties"`
+	Score       float64 `json:"score"`
+}
+
+// MinimaxScore represents a candidate's worst pairwise score under Minimax
+type MinimaxScore struct {
+	CandidateID   int `json:"candidate_id"`
+	WorstScore    int `json:"worst_score"`
+	WorstOpponent int `json:"worst_opponent,omitempty"`
+}
+
+// ValidateMinimaxVariant validates a Minimax variant name, defaulting to winning votes
+func ValidateMinimaxVariant(variant string) (string, error) {
+	switch variant {
+	case "":
+		return MinimaxWinningVotes, nil
+	case MinimaxWinningVotes, MinimaxMargins, MinimaxPairwiseOpposition:
+		return variant, nil
+	}
+	return "", fmt.Errorf("invalid minimax variant: %s", variant)
+}
+
+// ValidateCopelandTieScore validates the points a Copeland pairwise tie is worth
+func ValidateCopelandTieScore(tieScore float64) error {
+	if tieScore < 0 || tieScore > 1 {
+		return fmt.Errorf("tie_score must be between 0 and 1")
+	}
+	return nil
+}
+
+// CalculateRankedPairs implements the Ranked Pairs (Tideman) method.
+//
+// Every pairwise victory is sorted by the number of ballots supporting it (descending),
+// then by the number opposing it (ascending), then by winner and loser candidate ID.
+// Victories are locked in that order unless they would create a cycle with those
+// already locked. The final ranking is the topological order of the locked graph.
+func CalculateRankedPairs(ballots []RankedBallotWithRankings) *RankedPairsResult {
+	matrix := BuildPairwiseMatrix(ballots)
+	result := &RankedPairsResult{
+		Method:       "ranked_pairs",
+		Ranking:      []int{},
+		LockedEdges:  []RankedPairsEdge{},
+		SkippedEdges: []RankedPairsEdge{},
+		Matrix:       matrix.D,
+	}
+
+	n := matrix.Size()
+	if n == 0 {
+		return result
+	}
+
+	type edge struct{ i, j int }
+	edges := []edge{}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && matrix.Beats(i, j) {
+				edges = append(edges, edge{i, j})
+			}
+		}
+	}
+
+	sort.Slice(edges, func(a, b int) bool {
+		ea, eb := edges[a], edges[b]
+		if matrix.D[ea.i][ea.j] != matrix.D[eb.i][eb.j] {
+			return matrix.D[ea.i][ea.j] > matrix.D[eb.i][eb.j]
+		}
+		if matrix.D[ea.j][ea.i] != matrix.D[eb.j][eb.i] {
+			return matrix.D[ea.j][ea.i] < matrix.D[eb.j][eb.i]
+		}
+		if ea.i != eb.i {
+			return ea.i < eb.i
+		}
+		return ea.j < eb.j
+	})
+
+	locked := make([][]bool, n)
+	for i := range locked {
+		locked[i] = make([]bool, n)
+	}
+
+	for _, e := range edges {
+		reported := RankedPairsEdge{
+			Winner:  matrix.Candidates[e.i],
+			Loser:   matrix.Candidates[e.j],
+			For:     matrix.D[e.i][e.j],
+			Against: matrix.D[e.j][e.i],
+		}
+
+		// Locking i -> j creates a cycle if j already reaches i
+		if reaches(locked, e.j, e.i) {
+			result.SkippedEdges = append(result.SkippedEdges, reported)
+			continue
+		}
+
+		locked[e.i][e.j] = true
+		result.LockedEdges = append(result.LockedEdges, reported)
+	}
+
+	// Rank candidates by repeatedly taking the unbeaten candidate with the lowest ID
+	placed := make([]bool, n)
+	for len(result.Ranking) < n {
+		for i := 0; i < n; i++ {
+			if placed[i] {
+				continue
+			}
+
+			unbeaten := true
+			for j := 0; j < n; j++ {
+				if !placed[j] && locked[j][i] {
+					unbeaten = false
+					break
+				}
+			}
+
+			if unbeaten {
+				placed[i] = true
+				result.Ranking = append(result.Ranking, matrix.Candidates[i])
+				break
+			}
+		}
+	}
+
+	result.Winner = result.Ranking[0]
+	return result
+}
+
+// reaches reports whether the locked graph has a path from index from to index to
+func reaches(locked [][]bool, from, to int) bool {
+	visited := make([]bool, len(locked))
+	stack := []int{from}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == to {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for next, isLocked := range locked[current] {
+			if isLocked && !visited[next] {
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	return false
+}
+
+// CalculateMinimax implements the Minimax (Simpson–Kramer) method.
+//
+// Each candidate is scored by their worst pairwise result against any opponent and the
+// candidates with the smallest worst score win. With winning votes, a defeat scores the
+// opponent's votes and a win or tie scores zero; with margins, it scores the opponent's
+// votes minus the candidate's; with pairwise opposition, it scores the opponent's votes
+// whether or not the candidate lost.
+func CalculateMinimax(ballots []RankedBallotWithRankings, variant string) (*MinimaxResult, error) {
+	variant, err := ValidateMinimaxVariant(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := BuildPairwiseMatrix(ballots)
+	result := &MinimaxResult{
+		Method:  "minimax",
+		Variant: variant,
+		Winners: []int{},
+		Scores:  []MinimaxScore{},
+		Matrix:  matrix.D,
+	}
+
+	n := matrix.Size()
+	if n == 0 {
+		return result, nil
+	}
+
+	for x := 0; x < n; x++ {
+		score := MinimaxScore{CandidateID: matrix.Candidates[x]}
+		first := true
+
+		for y := 0; y < n; y++ {
+			if x == y {
+				continue
+			}
+
+			var s int
+			switch variant {
+			case MinimaxWinningVotes:
+				if matrix.Beats(y, x) {
+					s = matrix.D[y][x]
+				}
+			case MinimaxMargins:
+				s = matrix.D[y][x] - matrix.D[x][y]
+			case MinimaxPairwiseOpposition:
+				s = matrix.D[y][x]
+			}
+
+			if first || s > score.WorstScore {
+				score.WorstScore = s
+				score.WorstOpponent = matrix.Candidates[y]
+				first = false
+			}
+		}
+
+		// An undefeated candidate has no worst opponent under winning votes
+		if variant == MinimaxWinningVotes && score.WorstScore == 0 {
+			score.WorstOpponent = 0
+		}
+
+		result.Scores = append(result.Scores, score)
+	}
+
+	sort.SliceStable(result.Scores, func(i, j int) bool {
+		return result.Scores[i].WorstScore < result.Scores[j].WorstScore
+	})
+
+	for _, score := range result.Scores {
+		if score.WorstScore == result.Scores[0].WorstScore {
+			result.Winners = append(result.Winners, score.CandidateID)
+		}
+	}
+
+	return result, nil
+}
+
+// CalculateCopeland implements the Copeland method.
+//
+// Each candidate scores one point for every pairwise victory and tieScore points for
+// every pairwise tie; the candidates with the highest score win. A tie score of 0.5 is
+// classic Copeland, 0 counts only wins, and 1 treats ties as wins (Llull's method).
+func CalculateCopeland(ballots []RankedBallotWithRankings, tieScore float64) (*CopelandResult, error) {
+	if err := ValidateCopelandTieScore(tieScore); err != nil {
+		return nil, err
+	}
+
+	matrix := BuildPairwiseMatrix(ballots)
+	result := &CopelandResult{
+		Method:   "copeland",
+		TieScore: tieScore,
+		Winners:  []int{},
+		Scores:   []CopelandScore{},
+		Matrix:   matrix.D,
+	}
+
+	n := matrix.Size()
+	for x := 0; x < n; x++ {
+		score := CopelandScore{CandidateID: matrix.Candidates[x]}
+		for y := 0; y < n; y++ {
+			switch {
+			case x == y:
+			case matrix.Beats(x, y):
+				score.Wins++
+			case matrix.Beats(y, x):
+				score.Losses++
+			default:
+				score.Ties++
+			}
+		}
+		score.Score = float64(score.Wins) + tieScore*float64(score.Ties)
+		result.Scores = append(result.Scores, score)
+	}
+
+	sort.SliceStable(result.Scores, func(i, j int) bool {
+		return result.Scores[i].Score > result.Scores[j].Score
+	})
+
+	for _, score := range result.Scores {
+		if score.Score == result.Scores[0].Score {
+			result.Winners = append(result.Winners, score.CandidateID)
+		}
+	}
+
+	return result, nil
+}
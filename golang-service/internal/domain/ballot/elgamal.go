@@ -0,0 +1,369 @@
+package ballot
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+)
+
+// ElGamalGroupName identifies the group encrypted ballots are encrypted in
+const ElGamalGroupName = "rfc3526-modp-2048"
+
+// rfc3526Prime2048 is the prime of the 2048-bit MODP group 14 of RFC 3526
+const rfc3526Prime2048 = "" +
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD1" +
+	"29024E088A67CC74020BBEA63B139B22514A08798E3404DD" +
+	"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245" +
+	"E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+	"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3D" +
+	"C2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F" +
+	"83655D23DCA3AD961C62F356208552BB9ED529077096966D" +
+	"670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B" +
+	"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9" +
+	"DE2BCBF6955817183995497CEA956AE515D2261898FA0510" +
+	"15728E5A8AACAA68FFFFFFFFFFFFFFFF"
+
+// ElGamalGroup is the group encrypted ballots use: the subgroup of quadratic residues
+// modulo the RFC 3526 group 14 safe prime P, of prime order Q = (P-1)/2, generated by G = 2
+var ElGamalGroup = func() *Group {
+	p, _ := new(big.Int).SetString(rfc3526Prime2048, 16)
+	return &Group{
+		P:           p,
+		Q:           new(big.Int).Rsh(p, 1),
+		G:           big.NewInt(2),
+		ElementSize: (p.BitLen() + 7) / 8,
+	}
+}()
+
+// Group is a prime-order subgroup of the integers modulo a safe prime
+type Group struct {
+	P *big.Int
+	Q *big.Int
+	G *big.Int
+
+	// ElementSize is the byte length of an encoded group element
+	ElementSize int
+}
+
+// IsElement reports whether x is an element of the group
+func (g *Group) IsElement(x *big.Int) bool {
+	if x.Sign() <= 0 || x.Cmp(g.P) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(x, g.Q, g.P).Cmp(big.NewInt(1)) == 0
+}
+
+// RandomExponent returns a uniformly random non-zero exponent below Q
+func (g *Group) RandomExponent(random io.Reader) (*big.Int, error) {
+	r, err := rand.Int(random, new(big.Int).Sub(g.Q, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random exponent: %v", err)
+	}
+	return r.Add(r, big.NewInt(1)), nil
+}
+
+// Exp returns G^x mod P
+func (g *Group) Exp(x *big.Int) *big.Int {
+	return new(big.Int).Exp(g.G, x, g.P)
+}
+
+// EncodeElement encodes a group element as ElementSize big-endian bytes
+func (g *Group) EncodeElement(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, g.ElementSize))
+}
+
+// DecodeElement decodes ElementSize big-endian bytes, rejecting values outside the group
+func (g *Group) DecodeElement(data []byte) (*big.Int, error) {
+	if len(data) != g.ElementSize {
+		return nil, fmt.Errorf("group element must be %d bytes, got %d", g.ElementSize, len(data))
+	}
+	x := new(big.Int).SetBytes(data)
+	if !g.IsElement(x) {
+		return nil, fmt.Errorf("value is not an element of the group")
+	}
+	return x, nil
+}
+
+// ElGamalCiphertext is an exponential ElGamal encryption of m under public key h:
+// Alpha = G^r and Beta = G^m * h^r for a random r
+type ElGamalCiphertext struct {
+	Alpha *big.Int
+	Beta  *big.Int
+}
+
+// Encrypt encrypts m under the public key with randomness r
+func (g *Group) Encrypt(publicKey *big.Int, m int64, r *big.Int) ElGamalCiphertext {
+	beta := new(big.Int).Exp(publicKey, r, g.P)
+	beta.Mul(beta, g.Exp(big.NewInt(m)))
+	return ElGamalCiphertext{
+		Alpha: g.Exp(r),
+		Beta:  beta.Mod(beta, g.P),
+	}
+}
+
+// Multiply returns the ciphertext of the sum of the plaintexts of a and b
+func (g *Group) Multiply(a, b ElGamalCiphertext) ElGamalCiphertext {
+	alpha := new(big.Int).Mul(a.Alpha, b.Alpha)
+	beta := new(big.Int).Mul(a.Beta, b.Beta)
+	return ElGamalCiphertext{
+		Alpha: alpha.Mod(alpha, g.P),
+		Beta:  beta.Mod(beta, g.P),
+	}
+}
+
+// DecryptionFactor returns Alpha^secret, which removes the key's mask from Beta
+func (g *Group) DecryptionFactor(c ElGamalCiphertext, secret *big.Int) *big.Int {
+	return new(big.Int).Exp(c.Alpha, secret, g.P)
+}
+
+// DecryptWithFactor recovers a plaintext between 0 and max from a ciphertext and its decryption factor
+func (g *Group) DecryptWithFactor(c ElGamalCiphertext, factor *big.Int, max int) (int, error) {
+	inverse := new(big.Int).ModInverse(factor, g.P)
+	if inverse == nil {
+		return 0, fmt.Errorf("decryption factor is not invertible")
+	}
+	gm := inverse.Mul(inverse, c.Beta)
+	return g.DiscreteLog(gm.Mod(gm, g.P), max)
+}
+
+// DiscreteLog finds m between 0 and max with G^m = gm, by baby-step giant-step in O(sqrt(max)) steps
+func (g *Group) DiscreteLog(gm *big.Int, max int) (int, error) {
+	steps := 1
+	for steps*steps <= max {
+		steps++
+	}
+
+	babySteps := make(map[string]int, steps)
+	power := big.NewInt(1)
+	for j := 0; j < steps; j++ {
+		babySteps[string(power.Bytes())] = j
+		power = power.Mul(power, g.G).Mod(power, g.P)
+	}
+
+	// power is now G^steps; each giant step divides by it
+	giantStep := new(big.Int).ModInverse(power, g.P)
+	gamma := new(big.Int).Set(gm)
+	for i := 0; i*steps <= max; i++ {
+		if j, ok := babySteps[string(gamma.Bytes())]; ok && i*steps+j <= max {
+			return i*steps + j, nil
+		}
+		gamma.Mul(gamma, giantStep).Mod(gamma, g.P)
+	}
+
+	return 0, fmt.Errorf("plaintext is not between 0 and %d", max)
+}
+
+// BallotCiphertext holds one ElGamal ciphertext per candidate of the election slate, in ballot order.
+// Each encrypts 1 for a selected candidate and 0 otherwise. Encoded, it is each candidate's
+// Alpha then Beta as ElementSize-byte big-endian integers.
+type BallotCiphertext []ElGamalCiphertext
+
+// EncryptBallot encrypts one vote per candidate under the election public key,
+// returning the randomness used for each candidate's ciphertext
+func EncryptBallot(publicKey *big.Int, votes []int, random io.Reader) (BallotCiphertext, []*big.Int, error) {
+	g := ElGamalGroup
+	ciphertext := make(BallotCiphertext, len(votes))
+	nonces := make([]*big.Int, len(votes))
+	for i, vote := range votes {
+		r, err := g.RandomExponent(random)
+		if err != nil {
+			return nil, nil, err
+		}
+		ciphertext[i] = g.Encrypt(publicKey, int64(vote), r)
+		nonces[i] = r
+	}
+	return ciphertext, nonces, nil
+}
+
+// ParseBallotCiphertext decodes an encoded ballot ciphertext for an election with the given number of candidates
+func ParseBallotCiphertext(data []byte, candidates int) (BallotCiphertext, error) {
+	g := ElGamalGroup
+	if want := 2 * g.ElementSize * candidates; len(data) != want {
+		return nil, fmt.Errorf("invalid ciphertext: must be %d bytes for %d candidates, got %d", want, candidates, len(data))
+	}
+
+	ciphertext := make(BallotCiphertext, candidates)
+	for i := range ciphertext {
+		offset := 2 * g.ElementSize * i
+		alpha, err := g.DecodeElement(data[offset : offset+g.ElementSize])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ciphertext: candidate %d alpha: %v", i+1, err)
+		}
+		beta, err := g.DecodeElement(data[offset+g.ElementSize : offset+2*g.ElementSize])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ciphertext: candidate %d beta: %v", i+1, err)
+		}
+		ciphertext[i] = ElGamalCiphertext{Alpha: alpha, Beta: beta}
+	}
+
+	return ciphertext, nil
+}
+
+// Bytes encodes the ballot ciphertext
+func (c BallotCiphertext) Bytes() []byte {
+	g := ElGamalGroup
+	data := make([]byte, 0, 2*g.ElementSize*len(c))
+	for _, candidate := range c {
+		data = append(data, g.EncodeElement(candidate.Alpha)...)
+		data = append(data, g.EncodeElement(candidate.Beta)...)
+	}
+	return data
+}
+
+// NewBallotAggregate returns the ciphertext of an empty tally, an encryption of 0 for every candidate
+func NewBallotAggregate(candidates int) BallotCiphertext {
+	aggregate := make(BallotCiphertext, candidates)
+	for i := range aggregate {
+		aggregate[i] = ElGamalCiphertext{Alpha: big.NewInt(1), Beta: big.NewInt(1)}
+	}
+	return aggregate
+}
+
+// Add multiplies a ballot into the aggregate, adding its votes to every candidate's encrypted total
+func (c BallotCiphertext) Add(ballot BallotCiphertext) {
+	for i := range c {
+		c[i] = ElGamalGroup.Multiply(c[i], ballot[i])
+	}
+}
+
+// DecodeBallotCiphertext decodes a base64 ballot ciphertext for an election with the given number of candidates
+func DecodeBallotCiphertext(ciphertext string, candidates int) (BallotCiphertext, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: must be valid base64: %v", err)
+	}
+	return ParseBallotCiphertext(data, candidates)
+}
+
+// DecodeCiphertext decodes the ballot's ciphertext for an election with the given number of candidates
+func (eb *EncryptedBallot) DecodeCiphertext(candidates int) (BallotCiphertext, error) {
+	return DecodeBallotCiphertext(eb.Ciphertext, candidates)
+}
+
+// ElectionKey is the ElGamal key pair encrypted ballots of an election are encrypted under.
+// Only the public key is stored, so reading the database does not decrypt any ballot. The secret key
+// of a single key is returned once, when it is generated, and the operator supplies it to decrypt the
+// aggregate of all ballots. A key generated by trustees has no secret key: Threshold of its trustees
+// decrypt the aggregate together.
+type ElectionKey struct {
+	ElectionID string    `json:"election_id" db:"election_id"`
+	Group      string    `json:"group" db:"-"`
+	PublicKey  string    `json:"public_key" db:"public_key"`
+	SecretKey  string    `json:"secret_key,omitempty" db:"-"`
+	Threshold  int       `json:"threshold,omitempty" db:"threshold"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsThreshold reports whether the key was generated by trustees
+func (k *ElectionKey) IsThreshold() bool {
+	return k.Threshold > 0
+}
+
+// GenerateElectionKey generates a new election key pair. Keys are hex encoded group elements and exponents.
+func GenerateElectionKey(electionID string, random io.Reader) (*ElectionKey, error) {
+	g := ElGamalGroup
+	secret, err := g.RandomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElectionKey{
+		ElectionID: electionID,
+		Group:      ElGamalGroupName,
+		PublicKey:  hex.EncodeToString(g.EncodeElement(g.Exp(secret))),
+		SecretKey:  hex.EncodeToString(secret.FillBytes(make([]byte, g.ElementSize))),
+	}, nil
+}
+
+// ParsePublicKey decodes the election public key
+func (k *ElectionKey) ParsePublicKey() (*big.Int, error) {
+	data, err := hex.DecodeString(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid election public key: %v", err)
+	}
+	publicKey, err := ElGamalGroup.DecodeElement(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid election public key: %v", err)
+	}
+	return publicKey, nil
+}
+
+// ParseSecretKey decodes the election secret key
+func (k *ElectionKey) ParseSecretKey() (*big.Int, error) {
+	data, err := hex.DecodeString(k.SecretKey)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid election secret key")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// CheckSecretKey decodes a hex secret key supplied for the election key and checks that it is the
+// secret key of the public key
+func (k *ElectionKey) CheckSecretKey(value string) (*big.Int, error) {
+	secret, err := (&ElectionKey{SecretKey: value}).ParseSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := k.ParsePublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if ElGamalGroup.Exp(secret).Cmp(publicKey) != 0 {
+		return nil, fmt.Errorf("invalid election secret key: it does not match the public key of election %s", k.ElectionID)
+	}
+	return secret, nil
+}
+
+// TallyRequest represents the secret key an operator supplies to decrypt the tally of a single-key election
+type TallyRequest struct {
+	SecretKey string `json:"secret_key"`
+}
+
+// CandidateTotal is the decrypted number of votes for a candidate
+type CandidateTotal struct {
+	CandidateID int `json:"candidate_id"`
+	Votes       int `json:"votes"`
+}
+
+// EncryptedAggregate is the product of an election's accepted ballot ciphertexts, base64 encoded.
+// Anyone can recompute it from the published ballots.
+type EncryptedAggregate struct {
+	ElectionID string `json:"election_id"`
+	Ballots    int    `json:"ballots"`
+	Aggregate  string `json:"aggregate"`
+}
+
+// EncryptedTally is the decrypted aggregate of an election's encrypted ballots
+type EncryptedTally struct {
+	EncryptedAggregate
+	Totals []CandidateTotal `json:"totals"`
+}
+
+// AggregateBallots multiplies the ciphertexts of every accepted ballot, returning the
+// aggregate and the number of ballots counted
+func AggregateBallots(ballots []*EncryptedBallot, candidates int) (BallotCiphertext, int, error) {
+	aggregate := NewBallotAggregate(candidates)
+	counted := 0
+	for _, encryptedBallot := range ballots {
+		if encryptedBallot.Status != BallotStatusAccepted {
+			continue
+		}
+		ciphertext, err := encryptedBallot.DecodeCiphertext(candidates)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode ballot %s: %v", encryptedBallot.BallotID, err)
+		}
+		aggregate.Add(ciphertext)
+		counted++
+	}
+	return aggregate, counted, nil
+}
+
+// ElectionKeyRepository defines repository interface for election keys
+type ElectionKeyRepository interface {
+	Create(key *ElectionKey) error
+	GetByElectionID(electionID string) (*ElectionKey, error)
+}
@@ -7,18 +7,29 @@ import (
 	"time"
 )
 
+// Statuses of a stored ballot
+const (
+	// BallotStatusAccepted is the status of a ballot that was accepted and is counted
+	BallotStatusAccepted = "accepted"
+
+	// BallotStatusSuperseded is the status of a ballot replaced by the same voter's later ballot
+	// in an election that allows revoting; it is kept but not counted
+	BallotStatusSuperseded = "superseded"
+)
+
 // EncryptedBallot represents an encrypted ballot for Q16
 type EncryptedBallot struct {
-	BallotID    string    `json:"ballot_id" db:"ballot_id"`
-	ElectionID  string    `json:"election_id" db:"election_id"`
-	VoterID     int       `json:"voter_id" db:"voter_id"`
-	Ciphertext  string    `json:"ciphertext" db:"ciphertext"`
-	ZKProof     string    `json:"zk_proof" db:"zk_proof"`
-	VoterPubkey string    `json:"voter_pubkey" db:"voter_pubkey"`
-	Nullifier   string    `json:"nullifier" db:"nullifier"`
-	Signature   string    `json:"signature" db:"signature"`
-	Status      string    `json:"status" db:"status"`
-	AnchoredAt  time.Time `json:"anchored_at" db:"anchored_at"`
+	BallotID     string    `json:"ballot_id" db:"ballot_id"`
+	ElectionID   string    `json:"election_id" db:"election_id"`
+	VoterID      int       `json:"voter_id" db:"voter_id"`
+	Ciphertext   string    `json:"ciphertext" db:"ciphertext"`
+	ZKProof      string    `json:"zk_proof" db:"zk_proof"`
+	VoterPubkey  string    `json:"voter_pubkey" db:"voter_pubkey"`
+	Nullifier    string    `json:"nullifier" db:"nullifier"`
+	Signature    string    `json:"signature" db:"signature"`
+	Status       string    `json:"status" db:"status"`
+	TrackingCode string    `json:"tracking_code,omitempty" db:"tracking_code"`
+	AnchoredAt   time.Time `json:"anchored_at" db:"anchored_at"`
 }
 
 // EncryptedBallotRequest represents the request payload for Q16
@@ -34,10 +45,11 @@ type EncryptedBallotRequest struct {
 
 // EncryptedBallotResponse represents the response for Q16
 type EncryptedBallotResponse struct {
-	BallotID   string    `json:"ballot_id"`
-	Status     string    `json:"status"`
-	Nullifier  string    `json:"nullifier"`
-	AnchoredAt time.Time `json:"anchored_at"`
+	BallotID     string    `json:"ballot_id"`
+	Status       string    `json:"status"`
+	Nullifier    string    `json:"nullifier"`
+	TrackingCode string    `json:"tracking_code,omitempty"`
+	AnchoredAt   time.Time `json:"anchored_at"`
 }
 
 // Validate validates the encrypted ballot request
@@ -154,16 +166,13 @@ func convertToHex(value string) string {
 	return hexValue
 }
 
-// ToEncryptedBallot converts request to domain model with generated ID
+// ToEncryptedBallot converts request to domain model. The ballot is assigned its ID when it is stored.
 func (req *EncryptedBallotRequest) ToEncryptedBallot() (*EncryptedBallot, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	ballotID := generateBallotID("b_")
-
 	return &EncryptedBallot{
-		BallotID:    ballotID,
 		ElectionID:  req.ElectionID,
 		VoterID:     req.VoterID,
 		Ciphertext:  req.Ciphertext,
@@ -171,30 +180,29 @@ func (req *EncryptedBallotRequest) ToEncryptedBallot() (*EncryptedBallot, error)
 		VoterPubkey: req.VoterPubkey,
 		Nullifier:   req.Nullifier,
 		Signature:   req.Signature,
-		Status:      "accepted",
+		Status:      BallotStatusAccepted,
 		AnchoredAt:  time.Now(),
 	}, nil
-} // ToResponse converts domain model to response
+}
+
+// ToResponse converts domain model to response
 func (eb *EncryptedBallot) ToResponse() *EncryptedBallotResponse {
 	return &EncryptedBallotResponse{
-		BallotID:   eb.BallotID,
-		Status:     eb.Status,
-		Nullifier:  eb.Nullifier,
-		AnchoredAt: eb.AnchoredAt,
+		BallotID:     eb.BallotID,
+		Status:       eb.Status,
+		Nullifier:    eb.Nullifier,
+		TrackingCode: eb.TrackingCode,
+		AnchoredAt:   eb.AnchoredAt,
 	}
 }
 
-// EncryptedBallotRepository defines repository interface for encrypted ballots
+// EncryptedBallotRepository defines repository interface for encrypted ballots. HasVoted reports
+// whether a voter has an accepted ballot in an election; the nullifier is chosen by the client,
+// so it does not identify the voter.
 type EncryptedBallotRepository interface {
 	Create(ballot *EncryptedBallot) error
 	GetByBallotID(ballotID string) (*EncryptedBallot, error)
 	GetByNullifier(nullifier string) (*EncryptedBallot, error)
+	HasVoted(electionID string, voterID int) (bool, error)
 	GetByElectionID(electionID string) ([]*EncryptedBallot, error)
 }
-
-// generateBallotID generates a unique ballot ID with prefix
-func generateBallotID(prefix string) string {
-	// Simple implementation - in production, use proper UUID or secure random
-	timestamp := time.Now().UnixNano()
-	return fmt.Sprintf("%s%x", prefix, timestamp%0xFFFF)
-}
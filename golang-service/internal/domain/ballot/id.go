@@ -0,0 +1,67 @@
+package ballot
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Prefixes of the IDs of each kind of ballot
+const (
+	EncryptedBallotIDPrefix = "b_"
+	RankedBallotIDPrefix    = "rb_"
+	ApprovalBallotIDPrefix  = "ab_"
+	ScoreBallotIDPrefix     = "sb_"
+)
+
+// MaxIDAttempts is how many IDs are tried before storing a ballot fails
+const MaxIDAttempts = 5
+
+// ErrBallotIDConflict is returned by repositories when a ballot ID is already taken
+var ErrBallotIDConflict = errors.New("ballot_id conflicts with a stored ballot")
+
+// IDGenerator generates ballot IDs
+type IDGenerator interface {
+	NewID(prefix string) (string, error)
+}
+
+// RandomIDGenerator generates 128-bit random IDs, encoded as 26 lowercase base32 characters.
+// IDs reveal nothing about when or in which order ballots were cast.
+type RandomIDGenerator struct {
+	random io.Reader
+}
+
+// NewRandomIDGenerator creates an ID generator that reads from the operating system's CSPRNG
+func NewRandomIDGenerator() *RandomIDGenerator {
+	return &RandomIDGenerator{random: rand.Reader}
+}
+
+// idEncoding is unpadded lowercase base32
+var idEncoding = base32.NewEncoding(strings.ToLower("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")).WithPadding(base32.NoPadding)
+
+// NewID returns a new random ID with the given prefix
+func (g *RandomIDGenerator) NewID(prefix string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := io.ReadFull(g.random, id); err != nil {
+		return "", fmt.Errorf("failed to generate ballot_id: %v", err)
+	}
+	return prefix + idEncoding.EncodeToString(id), nil
+}
+
+// CreateWithNewID stores a ballot under a new ID. create assigns the ID to the ballot and stores it;
+// if the ID is already taken, it is called again with another, up to MaxIDAttempts times.
+func CreateWithNewID(ids IDGenerator, prefix string, create func(ballotID string) error) error {
+	for attempt := 0; attempt < MaxIDAttempts; attempt++ {
+		ballotID, err := ids.NewID(prefix)
+		if err != nil {
+			return err
+		}
+		if err := create(ballotID); !errors.Is(err, ErrBallotIDConflict) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to find an unused ballot_id after %d attempts", MaxIDAttempts)
+}
@@ -0,0 +1,180 @@
+package ballot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryBallotStore stores ranked ballots by ID and, like the ballot tables' primary keys,
+// refuses a ballot whose ID is already taken
+type memoryBallotStore struct {
+	mu       sync.Mutex
+	ballots  map[string]*RankedBallot
+	rankings map[string][]BallotRanking
+}
+
+func newMemoryBallotStore() *memoryBallotStore {
+	return &memoryBallotStore{
+		ballots:  make(map[string]*RankedBallot),
+		rankings: make(map[string][]BallotRanking),
+	}
+}
+
+func (s *memoryBallotStore) Create(rankedBallot *RankedBallot, rankings []BallotRanking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ballots[rankedBallot.BallotID]; ok {
+		return fmt.Errorf("%w: %s", ErrBallotIDConflict, rankedBallot.BallotID)
+	}
+	stored := *rankedBallot
+	s.ballots[rankedBallot.BallotID] = &stored
+	s.rankings[rankedBallot.BallotID] = append([]BallotRanking(nil), rankings...)
+	return nil
+}
+
+// sequenceIDGenerator hands out the IDs it was given in order, then fails
+type sequenceIDGenerator struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (g *sequenceIDGenerator) NewID(prefix string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.ids) == 0 {
+		return "", errors.New("out of IDs")
+	}
+	id := g.ids[0]
+	g.ids = g.ids[1:]
+	return prefix + id, nil
+}
+
+func TestCreateWithNewIDConcurrent(t *testing.T) {
+	const workers = 64
+	const ballotsPerWorker = 250
+
+	ids := NewRandomIDGenerator()
+	store := newMemoryBallotStore()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < ballotsPerWorker; i++ {
+				req := &RankedBallotRequest{
+					ElectionID: "election-1",
+					VoterID:    w*ballotsPerWorker + i + 1,
+					Ranking:    RankingTiers{{1}, {2, 3}},
+					Timestamp:  time.Now(),
+				}
+				rankedBallot, rankings, err := req.ToRankedBallot()
+				if err != nil {
+					errs <- err
+					return
+				}
+				err = CreateWithNewID(ids, RankedBallotIDPrefix, func(ballotID string) error {
+					rankedBallot.AssignID(ballotID, rankings)
+					return store.Create(rankedBallot, rankings)
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if len(store.ballots) != workers*ballotsPerWorker {
+		t.Fatalf("stored %d ballots, expected %d", len(store.ballots), workers*ballotsPerWorker)
+	}
+	for ballotID, rankedBallot := range store.ballots {
+		if !strings.HasPrefix(ballotID, RankedBallotIDPrefix) || len(ballotID) != len(RankedBallotIDPrefix)+26 {
+			t.Fatalf("ballot ID %q is not a prefixed 128-bit base32 ID", ballotID)
+		}
+		if rankedBallot.BallotID != ballotID {
+			t.Fatalf("ballot stored under %s has ID %s", ballotID, rankedBallot.BallotID)
+		}
+		for _, ranking := range store.rankings[ballotID] {
+			if ranking.BallotID != ballotID {
+				t.Fatalf("ranking of ballot %s has ballot ID %s", ballotID, ranking.BallotID)
+			}
+		}
+	}
+}
+
+func TestCreateWithNewIDRetriesConflicts(t *testing.T) {
+	store := newMemoryBallotStore()
+	taken := &RankedBallot{BallotID: RankedBallotIDPrefix + "taken"}
+	if err := store.Create(taken, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := &sequenceIDGenerator{ids: []string{"taken", "taken", "free"}}
+	rankedBallot := &RankedBallot{ElectionID: "election-1"}
+	attempts := 0
+	err := CreateWithNewID(ids, RankedBallotIDPrefix, func(ballotID string) error {
+		attempts++
+		rankedBallot.AssignID(ballotID, nil)
+		return store.Create(rankedBallot, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Fatalf("made %d attempts, expected 3", attempts)
+	}
+	if rankedBallot.BallotID != RankedBallotIDPrefix+"free" {
+		t.Fatalf("ballot was stored as %s", rankedBallot.BallotID)
+	}
+}
+
+func TestCreateWithNewIDGivesUp(t *testing.T) {
+	store := newMemoryBallotStore()
+	if err := store.Create(&RankedBallot{BallotID: RankedBallotIDPrefix + "taken"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	taken := make([]string, MaxIDAttempts+1)
+	for i := range taken {
+		taken[i] = "taken"
+	}
+	ids := &sequenceIDGenerator{ids: taken}
+	attempts := 0
+	err := CreateWithNewID(ids, RankedBallotIDPrefix, func(ballotID string) error {
+		attempts++
+		return store.Create(&RankedBallot{BallotID: ballotID}, nil)
+	})
+	if err == nil {
+		t.Fatal("expected an error after every ID conflicted")
+	}
+	if attempts != MaxIDAttempts {
+		t.Fatalf("made %d attempts, expected %d", attempts, MaxIDAttempts)
+	}
+}
+
+func TestCreateWithNewIDReturnsOtherErrors(t *testing.T) {
+	ids := &sequenceIDGenerator{ids: []string{"a", "b"}}
+	failure := errors.New("duplicate nullifier")
+	attempts := 0
+	err := CreateWithNewID(ids, EncryptedBallotIDPrefix, func(string) error {
+		attempts++
+		return failure
+	})
+	if !errors.Is(err, failure) || attempts != 1 {
+		t.Fatalf("got %v after %d attempts, expected the store's error after 1", err, attempts)
+	}
+}
@@ -0,0 +1,200 @@
+package ballot
+
+import (
+	"math"
+	"sort"
+)
+
+// IRVExhaustedPolicy describes how ballots with no continuing candidates are handled
+const IRVExhaustedPolicy = "exhausted ballots are set aside and excluded from the majority threshold of later rounds"
+
+// IRVResult represents the result of an instant-runoff tabulation
+type IRVResult struct {
+	ElectionID       string     `json:"election_id"`
+	Method           string     `json:"method"`
+	Winner           int        `json:"winner"`
+	TotalBallots     int        `json:"total_ballots"`
+	ExhaustedBallots int        `json:"exhausted_ballots"`
+	ExhaustedPolicy  string     `json:"exhausted_policy"`
+	Rounds           []IRVRound `json:"rounds"`
+}
+
+// IRVRound represents the first-preference counts of a single IRV round
+type IRVRound struct {
+	Round              int                 `json:"round"`
+	Counts             []IRVCandidateCount `json:"counts"`
+	ContinuingBallots  int                 `json:"continuing_ballots"`
+	ExhaustedBallots   int                 `json:"exhausted_ballots"`
+	NewlyExhausted     int                 `json:"newly_exhausted"`
+	MajorityThreshold  int                 `json:"majority_threshold"`
+	Eliminated         int                 `json:"eliminated,omitempty"`
+	EliminationTieWith []int               `json:"elimination_tie_with,omitempty"`
+}
+
+// IRVCandidateCount represents a candidate's first-preference votes in a round
+type IRVCandidateCount struct {
+	CandidateID int     `json:"candidate_id"`
+	Votes       float64 `json:"votes"`
+}
+
+// CalculateIRV implements instant-runoff voting for ranked ballots.
+//
+// Each round, every ballot counts for its highest-ranked continuing candidate; a ballot
+// whose highest tier with continuing candidates ranks several of them equally is split
+// equally between them. A candidate with more than half of the continuing ballots wins
+// (MajorityThreshold is the whole number of votes that guarantees it). Otherwise the
+// candidate with the fewest votes is eliminated. Ties for last place are broken
+// by comparing the tied candidates' counts in earlier rounds, latest first; if they
+// were tied in every round, the candidate with the highest ID is eliminated.
+func CalculateIRV(ballots []RankedBallotWithRankings) *IRVResult {
+	result := &IRVResult{
+		Method:          "irv",
+		TotalBallots:    len(ballots),
+		ExhaustedPolicy: IRVExhaustedPolicy,
+		Rounds:          []IRVRound{},
+	}
+
+	preferences, candidates := orderedPreferences(ballots)
+	continuing := make(map[int]bool)
+	for _, candidate := range candidates {
+		continuing[candidate] = true
+	}
+
+	if len(continuing) == 0 {
+		result.ExhaustedBallots = len(ballots)
+		return result
+	}
+
+	// history[candidate] holds the candidate's votes in every completed round
+	history := make(map[int][]float64)
+	exhausted := 0
+
+	for round := 1; len(continuing) > 0; round++ {
+		counts := make(map[int]float64)
+		for candidate := range continuing {
+			counts[candidate] = 0
+		}
+
+		roundExhausted := 0
+		for _, prefs := range preferences {
+			top := topContinuing(prefs, continuing)
+			if len(top) == 0 {
+				roundExhausted++
+				continue
+			}
+			for _, candidate := range top {
+				counts[candidate] += 1 / float64(len(top))
+			}
+		}
+
+		// Round split votes so that equal shares compare as equal
+		for candidate, votes := range counts {
+			counts[candidate] = roundVotes(votes)
+		}
+
+		active := len(ballots) - roundExhausted
+		irvRound := IRVRound{
+			Round:             round,
+			Counts:            sortedIRVCounts(counts),
+			ContinuingBallots: active,
+			ExhaustedBallots:  roundExhausted,
+			NewlyExhausted:    roundExhausted - exhausted,
+			MajorityThreshold: active/2 + 1,
+		}
+		exhausted = roundExhausted
+
+		for candidate, votes := range counts {
+			history[candidate] = append(history[candidate], votes)
+		}
+
+		// A single continuing candidate holds every continuing ballot
+		for _, count := range irvRound.Counts {
+			if count.Votes*2 > float64(active) || len(continuing) == 1 {
+				result.Winner = count.CandidateID
+				break
+			}
+		}
+
+		if result.Winner != 0 {
+			result.Rounds = append(result.Rounds, irvRound)
+			break
+		}
+
+		eliminated, tied := irvLowestCandidate(counts, history)
+		irvRound.Eliminated = eliminated
+		if len(tied) > 1 {
+			irvRound.EliminationTieWith = tied
+		}
+		delete(continuing, eliminated)
+
+		result.Rounds = append(result.Rounds, irvRound)
+	}
+
+	result.ExhaustedBallots = exhausted
+	return result
+}
+
+// topContinuing returns the continuing candidates of a ballot's highest tier that has any
+func topContinuing(prefs [][]int, continuing map[int]bool) []int {
+	for _, tier := range prefs {
+		top := []int{}
+		for _, candidate := range tier {
+			if continuing[candidate] {
+				top = append(top, candidate)
+			}
+		}
+		if len(top) > 0 {
+			return top
+		}
+	}
+	return nil
+}
+
+// sortedIRVCounts orders round counts by votes (descending), then candidate ID
+func sortedIRVCounts(counts map[int]float64) []IRVCandidateCount {
+	sorted := make([]IRVCandidateCount, 0, len(counts))
+	for candidate, votes := range counts {
+		sorted = append(sorted, IRVCandidateCount{CandidateID: candidate, Votes: votes})
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Votes != sorted[j].Votes {
+			return sorted[i].Votes > sorted[j].Votes
+		}
+		return sorted[i].CandidateID < sorted[j].CandidateID
+	})
+
+	return sorted
+}
+
+// irvLowestCandidate picks the candidate to eliminate and returns every candidate tied for last place
+func irvLowestCandidate(counts map[int]float64, history map[int][]float64) (int, []int) {
+	lowest := math.Inf(1)
+	for _, votes := range counts {
+		lowest = math.Min(lowest, votes)
+	}
+
+	tied := []int{}
+	for candidate, votes := range counts {
+		if votes == lowest {
+			tied = append(tied, candidate)
+		}
+	}
+	sort.Ints(tied)
+
+	candidates := make([]int, len(tied))
+	copy(candidates, tied)
+
+	// Break ties by the most recent earlier round in which the candidates differed
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := history[candidates[i]], history[candidates[j]]
+		for round := len(a) - 2; round >= 0; round-- {
+			if a[round] != b[round] {
+				return a[round] < b[round]
+			}
+		}
+		return candidates[i] > candidates[j]
+	})
+
+	return candidates[0], tied
+}
@@ -0,0 +1,82 @@
+package ballot
+
+import "fmt"
+
+// Pairwise counting rules for candidates a ballot leaves unranked
+const (
+	// UnrankedIgnore counts a pair only on ballots that rank both candidates
+	UnrankedIgnore = "ignore"
+	// UnrankedBelowRanked prefers every ranked candidate to every unranked one
+	UnrankedBelowRanked = "below_ranked"
+)
+
+// Pairwise counting rules for candidates a ballot ranks equally
+const (
+	// EqualRankingIgnore counts an equally ranked pair for neither candidate
+	EqualRankingIgnore = "ignore"
+	// EqualRankingCountBoth counts an equally ranked pair once for each candidate
+	EqualRankingCountBoth = "count_both"
+)
+
+// PairwiseOptions configures how ballots are counted into a pairwise matrix
+type PairwiseOptions struct {
+	Unranked     string `json:"unranked"`
+	EqualRanking string `json:"equal_ranking"`
+}
+
+// PairwiseMatrix holds head-to-head preference counts between candidates.
+// D[i][j] is the number of ballots ranking Candidates[i] above Candidates[j].
+type PairwiseMatrix struct {
+	Candidates []int
+	D          [][]int
+}
+
+// Validate validates and normalizes the pairwise options.
+// By default ranked candidates are preferred to unranked ones and equal rankings count for neither.
+func (o *PairwiseOptions) Validate() error {
+	if o.Unranked == "" {
+		o.Unranked = UnrankedBelowRanked
+	}
+	if o.Unranked != UnrankedIgnore && o.Unranked != UnrankedBelowRanked {
+		return fmt.Errorf("invalid unranked option: %s", o.Unranked)
+	}
+
+	if o.EqualRanking == "" {
+		o.EqualRanking = EqualRankingIgnore
+	}
+	if o.EqualRanking != EqualRankingIgnore && o.EqualRanking != EqualRankingCountBoth {
+		return fmt.Errorf("invalid equal_ranking option: %s", o.EqualRanking)
+	}
+
+	return nil
+}
+
+// BuildPairwiseMatrix counts pairwise preferences over every candidate ranked on any ballot
+// with the default options: a ballot prefers every candidate it ranks to every candidate it
+// leaves unranked, and an equally ranked pair counts for neither candidate.
+func BuildPairwiseMatrix(ballots []RankedBallotWithRankings) *PairwiseMatrix {
+	return CountPairwiseMatrix(ballots, PairwiseOptions{
+		Unranked:     UnrankedBelowRanked,
+		EqualRanking: EqualRankingIgnore,
+	})
+}
+
+// CountPairwiseMatrix counts pairwise preferences over every candidate ranked on any ballot,
+// applying the given rules to unranked and equally ranked candidates. The options must be valid.
+func CountPairwiseMatrix(ballots []RankedBallotWithRankings, opts PairwiseOptions) *PairwiseMatrix {
+	tally := NewPairwiseTally()
+	for _, ballot := range ballots {
+		tally.Add(ballot.Rankings)
+	}
+	return tally.Matrix(opts)
+}
+
+// Size returns the number of candidates in the matrix
+func (m *PairwiseMatrix) Size() int {
+	return len(m.Candidates)
+}
+
+// Beats reports whether candidate index i wins the head-to-head contest against index j
+func (m *PairwiseMatrix) Beats(i, j int) bool {
+	return m.D[i][j] > m.D[j][i]
+}
@@ -0,0 +1,238 @@
+package ballot
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// ChaumPedersenProof is a non-interactive Chaum–Pedersen proof that two group elements have
+// the same discrete logarithm to two bases, made non-interactive with the Fiat–Shamir heuristic.
+// It is encoded in JSON as hex exponents.
+type ChaumPedersenProof struct {
+	Challenge *big.Int
+	Response  *big.Int
+}
+
+// ProveEqualLogs proves that h1 = g1^x and h2 = g2^x for the same secret x.
+// The context binds the proof to its use, so it cannot be replayed elsewhere.
+func (g *Group) ProveEqualLogs(context []byte, g1, h1, g2, h2, x *big.Int, random io.Reader) (ChaumPedersenProof, error) {
+	w, err := g.RandomExponent(random)
+	if err != nil {
+		return ChaumPedersenProof{}, err
+	}
+
+	a := new(big.Int).Exp(g1, w, g.P)
+	b := new(big.Int).Exp(g2, w, g.P)
+	c := g.Challenge(context, g1, h1, g2, h2, a, b)
+
+	// r = w + c*x mod Q
+	r := new(big.Int).Mul(c, x)
+	r.Add(r, w)
+	return ChaumPedersenProof{Challenge: c, Response: r.Mod(r, g.Q)}, nil
+}
+
+// VerifyEqualLogs verifies a proof that log_g1(h1) = log_g2(h2). The elements must be group elements.
+func (g *Group) VerifyEqualLogs(context []byte, g1, h1, g2, h2 *big.Int, proof ChaumPedersenProof) bool {
+	if !g.isExponent(proof.Challenge) || !g.isExponent(proof.Response) {
+		return false
+	}
+
+	// Recover the commitments a = g1^r / h1^c and b = g2^r / h2^c, then recompute the challenge
+	a := g.divExp(g1, proof.Response, h1, proof.Challenge)
+	b := g.divExp(g2, proof.Response, h2, proof.Challenge)
+	return g.Challenge(context, g1, h1, g2, h2, a, b).Cmp(proof.Challenge) == 0
+}
+
+// ProveKnowledge makes a Schnorr proof of knowledge of the secret x with h = G^x. It has the
+// challenge and response of a Chaum–Pedersen proof, and is encoded the same way.
+func (g *Group) ProveKnowledge(context []byte, h, x *big.Int, random io.Reader) (ChaumPedersenProof, error) {
+	w, err := g.RandomExponent(random)
+	if err != nil {
+		return ChaumPedersenProof{}, err
+	}
+
+	a := g.Exp(w)
+	c := g.Challenge(context, g.G, h, a)
+
+	// r = w + c*x mod Q
+	r := new(big.Int).Mul(c, x)
+	r.Add(r, w)
+	return ChaumPedersenProof{Challenge: c, Response: r.Mod(r, g.Q)}, nil
+}
+
+// VerifyKnowledge verifies a Schnorr proof of knowledge of log_G(h). h must be a group element.
+func (g *Group) VerifyKnowledge(context []byte, h *big.Int, proof ChaumPedersenProof) bool {
+	if !g.isExponent(proof.Challenge) || !g.isExponent(proof.Response) {
+		return false
+	}
+
+	// Recover the commitment a = G^r / h^c, then recompute the challenge
+	a := g.divExp(g.G, proof.Response, h, proof.Challenge)
+	return g.Challenge(context, g.G, h, a).Cmp(proof.Challenge) == 0
+}
+
+// DisjunctiveProof is a disjunctive Chaum–Pedersen proof that a ciphertext encrypts one of a range of
+// consecutive values, without revealing which. It holds one branch per value: the branch of the
+// encrypted value is a real proof and the others are simulated, with challenges summing to the
+// Fiat–Shamir challenge.
+type DisjunctiveProof []ChaumPedersenProof
+
+// ProveEncryptsOneOf proves that c, the encryption of m with randomness r under the public key,
+// encrypts a value between min and max
+func (g *Group) ProveEncryptsOneOf(context []byte, publicKey *big.Int, c ElGamalCiphertext, m, min, max int, r *big.Int, random io.Reader) (DisjunctiveProof, error) {
+	if m < min || m > max {
+		return nil, fmt.Errorf("plaintext %d is not between %d and %d", m, min, max)
+	}
+
+	proof := make(DisjunctiveProof, max-min+1)
+	commitments := make([]*big.Int, 0, 2*len(proof))
+	challengeSum := new(big.Int)
+	var w *big.Int
+	for j := range proof {
+		if min+j == m {
+			var err error
+			if w, err = g.RandomExponent(random); err != nil {
+				return nil, err
+			}
+			commitments = append(commitments, g.Exp(w), new(big.Int).Exp(publicKey, w, g.P))
+			continue
+		}
+
+		// Simulate the branch from a random challenge and response
+		challenge, err := g.RandomExponent(random)
+		if err != nil {
+			return nil, err
+		}
+		response, err := g.RandomExponent(random)
+		if err != nil {
+			return nil, err
+		}
+		proof[j] = ChaumPedersenProof{Challenge: challenge, Response: response}
+		challengeSum.Add(challengeSum, challenge)
+		commitments = append(commitments,
+			g.divExp(g.G, response, c.Alpha, challenge),
+			g.divExp(publicKey, response, g.unmask(c.Beta, min+j), challenge))
+	}
+
+	// The real branch takes the challenge left over, c = challenge - sum of simulated challenges mod Q
+	challenge := g.Challenge(context, append([]*big.Int{publicKey, c.Alpha, c.Beta}, commitments...)...)
+	challenge.Sub(challenge, challengeSum).Mod(challenge, g.Q)
+	response := new(big.Int).Mul(challenge, r)
+	response.Add(response, w).Mod(response, g.Q)
+	proof[m-min] = ChaumPedersenProof{Challenge: challenge, Response: response}
+
+	return proof, nil
+}
+
+// VerifyEncryptsOneOf verifies a proof that c encrypts a value between min and max under the public key.
+// The ciphertext must consist of group elements.
+func (g *Group) VerifyEncryptsOneOf(context []byte, publicKey *big.Int, c ElGamalCiphertext, min, max int, proof DisjunctiveProof) bool {
+	if len(proof) != max-min+1 {
+		return false
+	}
+
+	commitments := make([]*big.Int, 0, 2*len(proof))
+	challengeSum := new(big.Int)
+	for j, branch := range proof {
+		if !g.isExponent(branch.Challenge) || !g.isExponent(branch.Response) {
+			return false
+		}
+		challengeSum.Add(challengeSum, branch.Challenge)
+
+		// Each branch proves log_G(Alpha) = log_h(Beta / G^value)
+		commitments = append(commitments,
+			g.divExp(g.G, branch.Response, c.Alpha, branch.Challenge),
+			g.divExp(publicKey, branch.Response, g.unmask(c.Beta, min+j), branch.Challenge))
+	}
+
+	challenge := g.Challenge(context, append([]*big.Int{publicKey, c.Alpha, c.Beta}, commitments...)...)
+	return challenge.Cmp(challengeSum.Mod(challengeSum, g.Q)) == 0
+}
+
+// unmask returns beta / G^m, which is h^r when beta encrypts m
+func (g *Group) unmask(beta *big.Int, m int) *big.Int {
+	gm := g.Exp(big.NewInt(int64(m)))
+	gm.ModInverse(gm, g.P)
+	gm.Mul(gm, beta)
+	return gm.Mod(gm, g.P)
+}
+
+// Challenge hashes the context and group elements into a challenge exponent below Q.
+// The context and each element, encoded as ElementSize bytes, are hashed with SHA-256.
+func (g *Group) Challenge(context []byte, elements ...*big.Int) *big.Int {
+	hash := sha256.New()
+	hash.Write(context)
+	for _, element := range elements {
+		hash.Write(g.EncodeElement(element))
+	}
+	c := new(big.Int).SetBytes(hash.Sum(nil))
+	return c.Mod(c, g.Q)
+}
+
+// ProofContext builds a proof context from a domain separator and fields, each prefixed
+// with its length as a big-endian uint32
+func ProofContext(separator string, fields ...[]byte) []byte {
+	context := []byte(separator)
+	for _, field := range fields {
+		context = binary.BigEndian.AppendUint32(context, uint32(len(field)))
+		context = append(context, field...)
+	}
+	return context
+}
+
+// divExp returns base^e / divisor^d mod P
+func (g *Group) divExp(base, e, divisor, d *big.Int) *big.Int {
+	numerator := new(big.Int).Exp(base, e, g.P)
+	denominator := new(big.Int).Exp(divisor, d, g.P)
+	denominator.ModInverse(denominator, g.P)
+	numerator.Mul(numerator, denominator)
+	return numerator.Mod(numerator, g.P)
+}
+
+// isExponent reports whether x is a valid exponent, between 0 and Q-1
+func (g *Group) isExponent(x *big.Int) bool {
+	return x != nil && x.Sign() >= 0 && x.Cmp(g.Q) < 0
+}
+
+// chaumPedersenProofJSON is the JSON encoding of a ChaumPedersenProof
+type chaumPedersenProofJSON struct {
+	Challenge string `json:"challenge"`
+	Response  string `json:"response"`
+}
+
+// MarshalJSON encodes the proof with hex exponents
+func (p ChaumPedersenProof) MarshalJSON() ([]byte, error) {
+	if p.Challenge == nil || p.Response == nil {
+		return nil, fmt.Errorf("incomplete proof")
+	}
+	return json.Marshal(chaumPedersenProofJSON{
+		Challenge: hex.EncodeToString(p.Challenge.Bytes()),
+		Response:  hex.EncodeToString(p.Response.Bytes()),
+	})
+}
+
+// UnmarshalJSON decodes a proof with hex exponents
+func (p *ChaumPedersenProof) UnmarshalJSON(data []byte) error {
+	var encoded chaumPedersenProofJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	challenge, err := hex.DecodeString(encoded.Challenge)
+	if err != nil {
+		return fmt.Errorf("proof challenge must be valid hexadecimal: %v", err)
+	}
+	response, err := hex.DecodeString(encoded.Response)
+	if err != nil {
+		return fmt.Errorf("proof response must be valid hexadecimal: %v", err)
+	}
+
+	p.Challenge = new(big.Int).SetBytes(challenge)
+	p.Response = new(big.Int).SetBytes(response)
+	return nil
+}
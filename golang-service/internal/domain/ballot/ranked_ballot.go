@@ -1,23 +1,37 @@
 package ballot
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"time"
 )
 
-// RankedBallot represents a ranked ballot for Q19
+// RankedBallot represents a ranked ballot for Q19. Ballots of secret-ballot elections have
+// no VoterID or Timestamp.
 type RankedBallot struct {
-	BallotID   string    `json:"ballot_id" db:"ballot_id"`
-	ElectionID string    `json:"election_id" db:"election_id"`
-	VoterID    int       `json:"voter_id" db:"voter_id"`
-	Timestamp  time.Time `json:"timestamp" db:"timestamp"`
-	Status     string    `json:"status" db:"status"`
+	BallotID     string    `json:"ballot_id" db:"ballot_id"`
+	ElectionID   string    `json:"election_id" db:"election_id"`
+	VoterID      int       `json:"voter_id,omitempty" db:"voter_id"`
+	Timestamp    time.Time `json:"timestamp" db:"timestamp"`
+	Status       string    `json:"status" db:"status"`
+	TrackingCode string    `json:"tracking_code,omitempty" db:"tracking_code"`
 }
 
-// BallotRanking represents individual candidate rankings within a ballot
+// BallotRanking represents individual candidate rankings within a ballot.
+//
+// Candidates sharing a RankPosition are ranked equally, and candidates without a
+// ranking are unranked. Every tabulator treats these the same way:
+//   - a ranked candidate is preferred to every candidate the ballot leaves unranked,
+//     and unranked candidates are tied with each other
+//   - pairwise methods count an equally ranked pair for neither candidate
+//     (Schulze can count it for both with equal_ranking=count_both)
+//   - IRV and STV split a ballot equally between the continuing candidates of its
+//     highest tier that still has any
+//
+// ID is the row's serial key; it is not published because it would reveal the order ballots were stored in.
 type BallotRanking struct {
-	ID           int    `json:"id" db:"id"`
+	ID           int    `json:"-" db:"id"`
 	BallotID     string `json:"ballot_id" db:"ballot_id"`
 	CandidateID  int    `json:"candidate_id" db:"candidate_id"`
 	RankPosition int    `json:"rank_position" db:"rank_position"`
@@ -25,31 +39,55 @@ type BallotRanking struct {
 
 // RankedBallotRequest represents the request payload for Q19
 type RankedBallotRequest struct {
-	ElectionID string    `json:"election_id" validate:"required"`
-	VoterID    int       `json:"voter_id" validate:"required,min=1"`
-	Ranking    []int     `json:"ranking" validate:"required,min=1"`
-	Timestamp  time.Time `json:"timestamp" validate:"required"`
+	ElectionID string       `json:"election_id" validate:"required"`
+	VoterID    int          `json:"voter_id" validate:"required,min=1"`
+	Ranking    RankingTiers `json:"ranking" validate:"required,min=1"`
+	Timestamp  time.Time    `json:"timestamp" validate:"required"`
 }
 
-// RankedBallotResponse represents the response for Q19
-type RankedBallotResponse struct {
-	BallotID string `json:"ballot_id"`
-	Status   string `json:"status"`
+// RankingTiers lists a voter's preferences from most to least preferred, where each tier
+// holds candidates ranked equally. It accepts a flat list such as [3, 1, 4, 2], tiers such
+// as [[3], [1, 4], [2]], or a mix of both.
+type RankingTiers [][]int
+
+// UnmarshalJSON decodes each ranking entry as either a single candidate ID or a tier of IDs
+func (t *RankingTiers) UnmarshalJSON(data []byte) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("ranking must be an array: %v", err)
+	}
+
+	tiers := make(RankingTiers, len(entries))
+	for i, entry := range entries {
+		var candidateID int
+		if err := json.Unmarshal(entry, &candidateID); err == nil {
+			tiers[i] = []int{candidateID}
+			continue
+		}
+
+		if err := json.Unmarshal(entry, &tiers[i]); err != nil {
+			return fmt.Errorf("ranking entry at position %d must be a candidate_id or an array of candidate_ids", i)
+		}
+	}
+
+	*t = tiers
+	return nil
 }
 
-// SchulzeResult represents the result of Schulze method calculation
-type SchulzeResult struct {
-	ElectionID string                 `json:"election_id"`
-	Winners    []int                  `json:"winners"`
-	Rankings   []SchulzeCandidateRank `json:"rankings"`
-	Matrix     [][]int                `json:"pairwise_matrix,omitempty"`
+// Candidates returns every ranked candidate in ranking order
+func (t RankingTiers) Candidates() []int {
+	candidates := []int{}
+	for _, tier := range t {
+		candidates = append(candidates, tier...)
+	}
+	return candidates
 }
 
-// SchulzeCandidateRank represents a candidate's rank in Schulze results
-type SchulzeCandidateRank struct {
-	CandidateID int `json:"candidate_id"`
-	Rank        int `json:"rank"`
-	Score       int `json:"score"`
+// RankedBallotResponse represents the response for Q19
+type RankedBallotResponse struct {
+	BallotID     string `json:"ballot_id"`
+	Status       string `json:"status"`
+	TrackingCode string `json:"tracking_code,omitempty"`
 }
 
 // Validate validates the ranked ballot request
@@ -66,16 +104,21 @@ func (req *RankedBallotRequest) Validate() error {
 		return fmt.Errorf("ranking array cannot be empty")
 	}
 
-	// Validate ranking contains unique candidate IDs
+	// Validate ranking contains unique candidate IDs and no empty tiers
 	candidateSet := make(map[int]bool)
-	for i, candidateID := range req.Ranking {
-		if candidateID <= 0 {
-			return fmt.Errorf("candidate_id at position %d must be positive", i)
+	for i, tier := range req.Ranking {
+		if len(tier) == 0 {
+			return fmt.Errorf("ranking tier at position %d cannot be empty", i)
 		}
-		if candidateSet[candidateID] {
-			return fmt.Errorf("candidate_id %d appears multiple times in ranking", candidateID)
+		for _, candidateID := range tier {
+			if candidateID <= 0 {
+				return fmt.Errorf("candidate_id at position %d must be positive", i)
+			}
+			if candidateSet[candidateID] {
+				return fmt.Errorf("candidate_id %d appears multiple times in ranking", candidateID)
+			}
+			candidateSet[candidateID] = true
 		}
-		candidateSet[candidateID] = true
 	}
 
 	if req.Timestamp.IsZero() {
@@ -85,53 +128,87 @@ func (req *RankedBallotRequest) Validate() error {
 	return nil
 }
 
-// ToRankedBallot converts request to domain model with generated ID
+// ToRankedBallot converts request to domain model. The ballot is assigned its ID when it is stored.
 func (req *RankedBallotRequest) ToRankedBallot() (*RankedBallot, []BallotRanking, error) {
 	if err := req.Validate(); err != nil {
 		return nil, nil, err
 	}
 
-	ballotID := generateBallotID("rb_")
-
 	ballot := &RankedBallot{
-		BallotID:   ballotID,
 		ElectionID: req.ElectionID,
 		VoterID:    req.VoterID,
 		Timestamp:  req.Timestamp,
-		Status:     "accepted",
+		Status:     BallotStatusAccepted,
 	}
 
-	// Create individual rankings
-	rankings := make([]BallotRanking, len(req.Ranking))
-	for i, candidateID := range req.Ranking {
-		rankings[i] = BallotRanking{
-			BallotID:     ballotID,
-			CandidateID:  candidateID,
-			RankPosition: i + 1, // Rankings start from 1
+	// Create individual rankings; candidates in the same tier share a rank position
+	rankings := []BallotRanking{}
+	for i, tier := range req.Ranking {
+		for _, candidateID := range tier {
+			rankings = append(rankings, BallotRanking{
+				CandidateID:  candidateID,
+				RankPosition: i + 1, // Rankings start from 1
+			})
 		}
 	}
 
 	return ballot, rankings, nil
 }
 
+// AssignID sets the ID of the ballot and of each of its rankings
+func (rb *RankedBallot) AssignID(ballotID string, rankings []BallotRanking) {
+	rb.BallotID = ballotID
+	for i := range rankings {
+		rankings[i].BallotID = ballotID
+	}
+}
+
+// Anonymize removes the voter and timestamp from a ballot of a secret-ballot election and
+// returns the voter's participation, which is stored apart from the ballot
+func (rb *RankedBallot) Anonymize() *Participation {
+	participation := &Participation{ElectionID: rb.ElectionID, VoterID: rb.VoterID}
+	rb.VoterID = 0
+	rb.Timestamp = time.Time{}
+	return participation
+}
+
 // ToResponse converts domain model to response
 func (rb *RankedBallot) ToResponse() *RankedBallotResponse {
 	return &RankedBallotResponse{
-		BallotID: rb.BallotID,
-		Status:   rb.Status,
+		BallotID:     rb.BallotID,
+		Status:       rb.Status,
+		TrackingCode: rb.TrackingCode,
 	}
 }
 
-// CalculateSchulze implements the Schulze method for ranked choice voting
-func CalculateSchulze(ballots []RankedBallotWithRankings) *SchulzeResult {
-	if len(ballots) == 0 {
-		return &SchulzeResult{Winners: []int{}, Rankings: []SchulzeCandidateRank{}}
-	}
+// RankedBallotWithRankings combines ballot with its rankings for calculation
+type RankedBallotWithRankings struct {
+	Ballot   RankedBallot    `json:"ballot"`
+	Rankings []BallotRanking `json:"rankings"`
+}
 
-	// Get all unique candidates
+// orderedPreferences groups each ballot's candidates into tiers of equal rank position,
+// most preferred first, and returns every candidate ranked on any ballot
+func orderedPreferences(ballots []RankedBallotWithRankings) ([][][]int, []int) {
+	preferences := make([][][]int, len(ballots))
 	candidateSet := make(map[int]bool)
-	for _, ballot := range ballots {
-		for _, ranking := range ballot.Rankings {
+
+	for i, ballot := range ballots {
+		rankings := make([]BallotRanking, len(ballot.Rankings))
+		copy(rankings, ballot.Rankings)
+		sort.SliceStable(rankings, func(a, b int) bool {
+			if rankings[a].RankPosition != rankings[b].RankPosition {
+				return rankings[a].RankPosition < rankings[b].RankPosition
+			}
+			return rankings[a].CandidateID < rankings[b].CandidateID
+		})
+
+		for j, ranking := range rankings {
+			if j == 0 || ranking.RankPosition != rankings[j-1].RankPosition {
+				preferences[i] = append(preferences[i], []int{})
+			}
+			last := len(preferences[i]) - 1
+			preferences[i][last] = append(preferences[i][last], ranking.CandidateID)
 			candidateSet[ranking.CandidateID] = true
 		}
 	}
@@ -142,140 +219,36 @@ func CalculateSchulze(ballots []RankedBallotWithRankings) *SchulzeResult {
 	}
 	sort.Ints(candidates)
 
-	n := len(candidates)
-	if n == 0 {
-		return &SchulzeResult{Winners: []int{}, Rankings: []SchulzeCandidateRank{}}
-	}
-
-	// Create candidate index mapping
-	candidateIndex := make(map[int]int)
-	for i, candidate := range candidates {
-		candidateIndex[candidate] = i
-	}
-
-	// Initialize pairwise comparison matrix
-	d := make([][]int, n)
-	for i := range d {
-		d[i] = make([]int, n)
-	}
-
-	// Count pairwise preferences
-	for _, ballot := range ballots {
-		rankMap := make(map[int]int)
-		for _, ranking := range ballot.Rankings {
-			rankMap[ranking.CandidateID] = ranking.RankPosition
-		}
-
-		// Compare all pairs of candidates
-		for i, candidateA := range candidates {
-			for j, candidateB := range candidates {
-				if i != j {
-					rankA, hasA := rankMap[candidateA]
-					rankB, hasB := rankMap[candidateB]
-
-					// If both candidates are ranked and A is ranked higher (lower number)
-					if hasA && hasB && rankA < rankB {
-						d[i][j]++
-					}
-				}
-			}
-		}
-	}
-
-	// Floyd-Warshall algorithm to find strongest paths
-	p := make([][]int, n)
-	for i := range p {
-		p[i] = make([]int, n)
-		for j := range p[i] {
-			if i != j {
-				if d[i][j] > d[j][i] {
-					p[i][j] = d[i][j]
-				} else {
-					p[i][j] = 0
-				}
-			}
-		}
-	}
-
-	for k := 0; k < n; k++ {
-		for i := 0; i < n; i++ {
-			for j := 0; j < n; j++ {
-				if i != j && i != k && j != k {
-					p[i][j] = max(p[i][j], min(p[i][k], p[k][j]))
-				}
-			}
-		}
-	}
-
-	// Find winners (Condorcet winners)
-	winners := []int{}
-	rankings := []SchulzeCandidateRank{}
-
-	for i, candidate := range candidates {
-		isWinner := true
-		score := 0
-
-		for j := 0; j < n; j++ {
-			if i != j {
-				if p[j][i] > p[i][j] {
-					isWinner = false
-				}
-				score += p[i][j]
-			}
-		}
-
-		if isWinner {
-			winners = append(winners, candidate)
-		}
-
-		rankings = append(rankings, SchulzeCandidateRank{
-			CandidateID: candidate,
-			Score:       score,
-		})
-	}
-
-	// Sort rankings by score (descending)
-	sort.Slice(rankings, func(i, j int) bool {
-		return rankings[i].Score > rankings[j].Score
-	})
-
-	// Assign ranks
-	for i := range rankings {
-		rankings[i].Rank = i + 1
-	}
+	return preferences, candidates
+}
 
-	return &SchulzeResult{
-		Winners:  winners,
-		Rankings: rankings,
-		Matrix:   d,
-	}
+// Participation records that a voter cast a ranked ballot in an election. In secret-ballot elections it
+// is all that links the voter to the election, and it does not say which ballot is theirs or when it was cast.
+type Participation struct {
+	ElectionID string `json:"election_id" db:"election_id"`
+	VoterID    int    `json:"voter_id" db:"voter_id"`
 }
 
-// RankedBallotWithRankings combines ballot with its rankings for calculation
-type RankedBallotWithRankings struct {
-	Ballot   RankedBallot    `json:"ballot"`
-	Rankings []BallotRanking `json:"rankings"`
+// ParticipationResponse represents the response for a voter's participation in ranked elections
+type ParticipationResponse struct {
+	VoterID       int              `json:"voter_id"`
+	Participation []*Participation `json:"participation"`
+	Count         int              `json:"count"`
 }
 
-// RankedBallotRepository defines repository interface for ranked ballots
+// RankedBallotRepository defines repository interface for ranked ballots. CreateSecret stores a
+// ballot of a secret-ballot election together with the voter's participation, and fails if the
+// voter already took part. Replace stores a ballot of an election that allows revoting and supersedes
+// the voter's earlier ballot in it. GetByElectionID and StreamByElectionID return only counted ballots.
 type RankedBallotRepository interface {
 	Create(ballot *RankedBallot, rankings []BallotRanking) error
+	CreateSecret(ballot *RankedBallot, rankings []BallotRanking, participation *Participation) error
+	Replace(ballot *RankedBallot, rankings []BallotRanking) error
 	GetByBallotID(ballotID string) (*RankedBallot, []BallotRanking, error)
 	GetByElectionID(electionID string) ([]RankedBallotWithRankings, error)
+	StreamByElectionID(electionID string, fn func(RankedBallotWithRankings) error) error
+	GetPairwiseTally(electionID string) (*PairwiseTally, error)
 	GetByVoterID(voterID int) ([]*RankedBallot, error)
-}
-
-// Helper functions
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+	HasParticipated(electionID string, voterID int) (bool, error)
+	GetParticipation(voterID int) ([]*Participation, error)
 }
@@ -0,0 +1,295 @@
+package ballot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Schulze link strength variants, differing in how the strength of a pairwise defeat is measured
+const (
+	SchulzeWinningVotes = "winning_votes"
+	SchulzeMargins      = "margins"
+	SchulzeRatio        = "ratio"
+)
+
+// SchulzeOptions configures a Schulze tabulation.
+// Strength selects the link strength definition; the embedded PairwiseOptions
+// control how unranked and equally ranked candidates are counted.
+type SchulzeOptions struct {
+	Strength string `json:"strength"`
+	PairwiseOptions
+}
+
+// SchulzeResult represents the result of Schulze method calculation.
+// Winners holds every potential Schulze winner; Winner is the single winner after tie-breaking.
+type SchulzeResult struct {
+	ElectionID     string                 `json:"election_id"`
+	Options        SchulzeOptions         `json:"options"`
+	Winners        []int                  `json:"winners"`
+	Winner         int                    `json:"winner,omitempty"`
+	Rankings       []SchulzeCandidateRank `json:"rankings"`
+	TiedGroups     [][]int                `json:"tied_groups"`
+	TieBreaker     *TieBreaker            `json:"tie_breaker,omitempty"`
+	Matrix         [][]int                `json:"pairwise_matrix,omitempty"`
+	StrongestPaths [][]SchulzeLink        `json:"strongest_paths,omitempty"`
+}
+
+// SchulzeCandidateRank represents a candidate's rank in Schulze results.
+// Tier is the candidate's place in the Schulze order, shared by tied candidates;
+// Rank is their position once ties are broken. Score counts the candidates they
+// defeat in the Schulze order.
+type SchulzeCandidateRank struct {
+	CandidateID int  `json:"candidate_id"`
+	Rank        int  `json:"rank"`
+	Tier        int  `json:"tier"`
+	Score       int  `json:"score"`
+	TieBroken   bool `json:"tie_broken"`
+}
+
+// SchulzeLink is the strength of a pairwise defeat, given as the ballots for and against it.
+// The zero link means there is no path; a real link always has For > Against.
+type SchulzeLink struct {
+	For     int `json:"for"`
+	Against int `json:"against"`
+}
+
+// Validate validates and normalizes the Schulze options
+func (o *SchulzeOptions) Validate() error {
+	if o.Strength == "" {
+		o.Strength = SchulzeWinningVotes
+	}
+
+	switch o.Strength {
+	case SchulzeWinningVotes, SchulzeMargins, SchulzeRatio:
+	default:
+		return fmt.Errorf("invalid schulze strength: %s", o.Strength)
+	}
+
+	return o.PairwiseOptions.Validate()
+}
+
+// stronger reports whether link a is stronger than link b under a strength variant.
+//
+// With winning votes, the link with more votes for it is stronger, then the one with
+// fewer votes against it. With margins, the larger difference is stronger. With ratio,
+// the larger For/Against ratio is stronger, an unopposed link being infinitely strong.
+func (o SchulzeOptions) stronger(a, b SchulzeLink) bool {
+	if b == (SchulzeLink{}) {
+		return a != b
+	}
+	if a == (SchulzeLink{}) {
+		return false
+	}
+
+	switch o.Strength {
+	case SchulzeMargins:
+		return a.For-a.Against > b.For-b.Against
+	case SchulzeRatio:
+		if a.Against == 0 && b.Against == 0 {
+			return a.For > b.For
+		}
+		return a.For*b.Against > b.For*a.Against
+	default:
+		if a.For != b.For {
+			return a.For > b.For
+		}
+		return a.Against < b.Against
+	}
+}
+
+// CalculateSchulze implements the Schulze method for ranked choice voting.
+//
+// The link from a to b exists when more ballots prefer a to b than b to a, and its
+// strength is compared according to opts.Strength. Candidate a is placed above
+// candidate b when the strongest path from a to b is stronger than the one from b to a
+// (p[a][b] > p[b][a]). Candidates are grouped into tiers by repeatedly taking those not
+// defeated by any remaining candidate, and candidates sharing a tier are reported in
+// TiedGroups. Ties are then broken with a random-ballot tie-breaker seeded from the
+// election's ballots (see TieBreaker), which is only built when some tier is tied.
+func CalculateSchulze(ballots []RankedBallotWithRankings, opts SchulzeOptions) (*SchulzeResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	matrix := CountPairwiseMatrix(ballots, opts.PairwiseOptions)
+	return schulzeFromMatrix(matrix, opts, func(candidates []int) (*TieBreaker, error) {
+		return NewRandomBallotTieBreaker(ballots, candidates), nil
+	})
+}
+
+// CalculateSchulzeStream computes the same result as CalculateSchulze from ballots streamed
+// in ballot ID order. The pairwise matrix is built incrementally, so memory depends on the
+// number of candidates rather than ballots; the source is read once for the matrix and
+// again for the tie-breaker.
+func CalculateSchulzeStream(source RankedBallotSource, opts SchulzeOptions) (*SchulzeResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	tally := NewPairwiseTally()
+	err := source(func(ballot RankedBallotWithRankings) error {
+		tally.Add(ballot.Rankings)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return CalculateSchulzeFromTally(tally, source, opts)
+}
+
+// CalculateSchulzeFromTally computes the same result as CalculateSchulze from an already counted
+// pairwise tally. The ballots are only streamed from source when ties need breaking.
+func CalculateSchulzeFromTally(tally *PairwiseTally, source RankedBallotSource, opts SchulzeOptions) (*SchulzeResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	matrix := tally.Matrix(opts.PairwiseOptions)
+	return schulzeFromMatrix(matrix, opts, func(candidates []int) (*TieBreaker, error) {
+		return NewStreamedRandomBallotTieBreaker(source, candidates)
+	})
+}
+
+// schulzeFromMatrix orders the candidates of a pairwise matrix by the Schulze method,
+// breaking ties with the tie-breaker built by breakTies, which is only called when a tier is tied
+func schulzeFromMatrix(matrix *PairwiseMatrix, opts SchulzeOptions, breakTies func(candidates []int) (*TieBreaker, error)) (*SchulzeResult, error) {
+	result := &SchulzeResult{
+		Options:    opts,
+		Winners:    []int{},
+		Rankings:   []SchulzeCandidateRank{},
+		TiedGroups: [][]int{},
+	}
+
+	candidates := matrix.Candidates
+	d := matrix.D
+
+	n := matrix.Size()
+	if n == 0 {
+		return result, nil
+	}
+
+	// Floyd-Warshall algorithm to find strongest paths
+	p := make([][]SchulzeLink, n)
+	for i := range p {
+		p[i] = make([]SchulzeLink, n)
+		for j := range p[i] {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = SchulzeLink{For: d[i][j], Against: d[j][i]}
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && i != k && j != k {
+					// A path is as strong as its weakest link
+					link := p[i][k]
+					if opts.stronger(link, p[k][j]) {
+						link = p[k][j]
+					}
+					if opts.stronger(link, p[i][j]) {
+						p[i][j] = link
+					}
+				}
+			}
+		}
+	}
+
+	// Group candidates into tiers of the Schulze order
+	tiers := schulzeTiers(p, opts)
+	positions := map[int]int{}
+	for _, tier := range tiers {
+		if len(tier) > 1 {
+			tieBreaker, err := breakTies(candidates)
+			if err != nil {
+				return nil, err
+			}
+			result.TieBreaker = tieBreaker
+			positions = tieBreaker.Positions()
+			break
+		}
+	}
+
+	result.Matrix = d
+	result.StrongestPaths = p
+
+	for tier, group := range tiers {
+		ids := make([]int, len(group))
+		for i, index := range group {
+			ids[i] = candidates[index]
+		}
+		sort.Slice(ids, func(a, b int) bool {
+			return positions[ids[a]] < positions[ids[b]]
+		})
+
+		if tier == 0 {
+			result.Winners = append(result.Winners, ids...)
+			sort.Ints(result.Winners)
+		}
+		if len(ids) > 1 {
+			tied := make([]int, len(ids))
+			copy(tied, ids)
+			sort.Ints(tied)
+			result.TiedGroups = append(result.TiedGroups, tied)
+		}
+
+		for _, candidate := range ids {
+			index := sort.SearchInts(candidates, candidate)
+			score := 0
+			for j := 0; j < n; j++ {
+				if j != index && opts.stronger(p[index][j], p[j][index]) {
+					score++
+				}
+			}
+
+			result.Rankings = append(result.Rankings, SchulzeCandidateRank{
+				CandidateID: candidate,
+				Rank:        len(result.Rankings) + 1,
+				Tier:        tier + 1,
+				Score:       score,
+				TieBroken:   len(ids) > 1,
+			})
+		}
+	}
+
+	result.Winner = result.Rankings[0].CandidateID
+	return result, nil
+}
+
+// schulzeTiers partitions candidate indexes into tiers of the Schulze order.
+// Each tier holds the remaining candidates that no other remaining candidate defeats.
+func schulzeTiers(p [][]SchulzeLink, opts SchulzeOptions) [][]int {
+	n := len(p)
+	placed := make([]bool, n)
+	tiers := [][]int{}
+
+	for remaining := n; remaining > 0; {
+		tier := []int{}
+		for i := 0; i < n; i++ {
+			if placed[i] {
+				continue
+			}
+
+			undefeated := true
+			for j := 0; j < n; j++ {
+				if j != i && !placed[j] && opts.stronger(p[j][i], p[i][j]) {
+					undefeated = false
+					break
+				}
+			}
+			if undefeated {
+				tier = append(tier, i)
+			}
+		}
+
+		for _, i := range tier {
+			placed[i] = true
+		}
+		remaining -= len(tier)
+		tiers = append(tiers, tier)
+	}
+
+	return tiers
+}
@@ -0,0 +1,156 @@
+package ballot
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+// EncryptedBallotSigningContext prefixes every signed encrypted ballot message,
+// so a ballot signature cannot be replayed as a signature over anything else
+const EncryptedBallotSigningContext = "saracen-voting/encrypted-ballot/v1"
+
+// ValidateStrict validates the encrypted ballot request without coercing any field.
+// voter_pubkey must be a hex Ed25519 public key, nullifier must be hex, and ciphertext,
+// zk_proof and signature must be canonical standard base64; the signature must be 64 bytes.
+func (req *EncryptedBallotRequest) ValidateStrict() error {
+	if req.ElectionID == "" {
+		return fmt.Errorf("election_id is required")
+	}
+
+	if req.VoterID <= 0 {
+		return fmt.Errorf("voter_id must be positive")
+	}
+
+	if _, err := decodeStrictBase64(req.Ciphertext, "ciphertext"); err != nil {
+		return err
+	}
+	if _, err := decodeStrictBase64(req.ZKProof, "zk_proof"); err != nil {
+		return err
+	}
+
+	signature, err := decodeStrictBase64(req.Signature, "signature")
+	if err != nil {
+		return err
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("signature must be %d bytes, got %d", ed25519.SignatureSize, len(signature))
+	}
+
+	if _, err := decodeStrictHex(req.Nullifier, "nullifier"); err != nil {
+		return err
+	}
+	if _, err := ParseEd25519PublicKey(req.VoterPubkey); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SigningMessage returns the canonical message an encrypted ballot's signature covers:
+// the signing context followed by election_id, the decoded ciphertext, the decoded
+// zk_proof and the decoded nullifier, each prefixed with its length as a big-endian uint32.
+// The request must have passed ValidateStrict.
+func (req *EncryptedBallotRequest) SigningMessage() ([]byte, error) {
+	ciphertext, err := decodeStrictBase64(req.Ciphertext, "ciphertext")
+	if err != nil {
+		return nil, err
+	}
+	zkProof, err := decodeStrictBase64(req.ZKProof, "zk_proof")
+	if err != nil {
+		return nil, err
+	}
+	nullifier, err := decodeStrictHex(req.Nullifier, "nullifier")
+	if err != nil {
+		return nil, err
+	}
+
+	return EncryptedBallotSigningMessage(req.ElectionID, ciphertext, zkProof, nullifier), nil
+}
+
+// EncryptedBallotSigningMessage builds the canonical signed message of an encrypted ballot
+func EncryptedBallotSigningMessage(electionID string, ciphertext, zkProof, nullifier []byte) []byte {
+	return ProofContext(EncryptedBallotSigningContext, []byte(electionID), ciphertext, zkProof, nullifier)
+}
+
+// VerifySignature verifies the request's Ed25519 signature over its canonical message.
+// The request must have passed ValidateStrict.
+func (req *EncryptedBallotRequest) VerifySignature() error {
+	publicKey, err := ParseEd25519PublicKey(req.VoterPubkey)
+	if err != nil {
+		return err
+	}
+
+	signature, err := decodeStrictBase64(req.Signature, "signature")
+	if err != nil {
+		return err
+	}
+
+	message, err := req.SigningMessage()
+	if err != nil {
+		return err
+	}
+
+	// ed25519.Verify rejects non-canonical signature scalars
+	if !ed25519.Verify(publicKey, message, signature) {
+		return fmt.Errorf("invalid signature: signature does not verify for voter_pubkey")
+	}
+
+	return nil
+}
+
+// ParseEd25519PublicKey decodes a hex Ed25519 public key, rejecting encodings that are
+// not canonical, are not a point on the curve, or are a point of small order
+func ParseEd25519PublicKey(value string) (ed25519.PublicKey, error) {
+	key, err := decodeStrictHex(value, "voter_pubkey")
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid voter_pubkey: must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+
+	point, err := new(edwards25519.Point).SetBytes(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid voter_pubkey: not a point on the curve")
+	}
+	// SetBytes accepts non-canonical encodings of valid points
+	if !bytes.Equal(point.Bytes(), key) {
+		return nil, fmt.Errorf("invalid voter_pubkey: point encoding is not canonical")
+	}
+
+	// A key of small order verifies signatures that do not depend on the message
+	if new(edwards25519.Point).MultByCofactor(point).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("invalid voter_pubkey: point has small order")
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+// decodeStrictBase64 decodes canonical padded standard base64
+func decodeStrictBase64(value, fieldName string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s is required", fieldName)
+	}
+	decoded, err := base64.StdEncoding.Strict().DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be valid base64: %v", fieldName, err)
+	}
+	return decoded, nil
+}
+
+// decodeStrictHex decodes hexadecimal without a 0x prefix
+func decodeStrictHex(value, fieldName string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s is required", fieldName)
+	}
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be valid hexadecimal: %v", fieldName, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,575 @@
+package ballot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// STV surplus transfer rules
+const (
+	STVTransferGregory = "gregory"
+	STVTransferMeek    = "meek"
+)
+
+// STV candidate statuses
+const (
+	stvHopeful  = "hopeful"
+	stvElected  = "elected"
+	stvExcluded = "excluded"
+)
+
+const (
+	// meekTolerance is how close an elected candidate's votes must be to the quota for Meek to converge
+	meekTolerance = 1e-9
+	// meekMaxIterations bounds the keep factor iteration of a single Meek round
+	meekMaxIterations = 1000
+)
+
+// STVOptions configures a Single Transferable Vote tabulation
+type STVOptions struct {
+	Seats        int    `json:"seats"`
+	TransferRule string `json:"transfer_rule"`
+}
+
+// STVResult represents the result of a Single Transferable Vote tabulation
+type STVResult struct {
+	ElectionID     string     `json:"election_id"`
+	Method         string     `json:"method"`
+	Seats          int        `json:"seats"`
+	TransferRule   string     `json:"transfer_rule"`
+	QuotaType      string     `json:"quota_type"`
+	Quota          float64    `json:"quota"`
+	TotalBallots   int        `json:"total_ballots"`
+	Elected        []int      `json:"elected"`
+	ExhaustedVotes float64    `json:"exhausted_votes"`
+	Rounds         []STVRound `json:"rounds"`
+}
+
+// STVRound represents the vote totals of a single STV round and the action taken
+type STVRound struct {
+	Round     int                 `json:"round"`
+	Quota     float64             `json:"quota"`
+	Tallies   []STVCandidateTally `json:"tallies"`
+	Exhausted float64             `json:"exhausted"`
+	Elected   []int               `json:"elected,omitempty"`
+	Excluded  int                 `json:"excluded,omitempty"`
+	Transfer  *STVTransfer        `json:"transfer,omitempty"`
+}
+
+// STVCandidateTally represents a candidate's votes in a round.
+// KeepFactor is only reported for Meek tabulations.
+type STVCandidateTally struct {
+	CandidateID int      `json:"candidate_id"`
+	Votes       float64  `json:"votes"`
+	Status      string   `json:"status"`
+	KeepFactor  *float64 `json:"keep_factor,omitempty"`
+}
+
+// STVTransfer records how a candidate's ballots were passed on in a Gregory tabulation
+type STVTransfer struct {
+	FromCandidate int                   `json:"from_candidate"`
+	Reason        string                `json:"reason"`
+	TransferValue float64               `json:"transfer_value"`
+	Transfers     []STVTransferredVotes `json:"transfers"`
+	Exhausted     float64               `json:"exhausted"`
+}
+
+// STVTransferredVotes represents the fractional votes received by one candidate in a transfer
+type STVTransferredVotes struct {
+	ToCandidate int     `json:"to_candidate"`
+	Votes       float64 `json:"votes"`
+}
+
+// Validate validates and normalizes the STV options
+func (o *STVOptions) Validate() error {
+	if o.Seats < 1 {
+		return fmt.Errorf("seats must be at least 1")
+	}
+
+	if o.TransferRule == "" {
+		o.TransferRule = STVTransferGregory
+	}
+
+	if o.TransferRule != STVTransferGregory && o.TransferRule != STVTransferMeek {
+		return fmt.Errorf("invalid transfer_rule: %s", o.TransferRule)
+	}
+
+	return nil
+}
+
+// CalculateSTV implements the Single Transferable Vote for multi-seat elections.
+//
+// The Droop quota is used. With the Gregory rule, quota is floor(valid/(seats+1))+1 and
+// the surplus of an elected candidate is transferred by multiplying the weight of each
+// of their ballots by surplus/votes (weighted inclusive Gregory). With the Meek rule,
+// every elected candidate keeps a fraction of each ballot reaching them, and the keep
+// factors and the quota (continuing votes/(seats+1)) are iterated until every elected
+// candidate holds exactly the quota. When no candidate reaches the quota, the candidate
+// with the fewest votes is excluded, breaking ties as in CalculateIRV.
+func CalculateSTV(ballots []RankedBallotWithRankings, opts STVOptions) (*STVResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &STVResult{
+		Method:       "stv",
+		Seats:        opts.Seats,
+		TransferRule: opts.TransferRule,
+		QuotaType:    "droop",
+		TotalBallots: len(ballots),
+		Elected:      []int{},
+		Rounds:       []STVRound{},
+	}
+
+	preferences, candidates := orderedPreferences(ballots)
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	if opts.TransferRule == STVTransferMeek {
+		calculateMeek(result, preferences, candidates, opts.Seats)
+	} else {
+		calculateGregory(result, preferences, candidates, opts.Seats)
+	}
+
+	return result, nil
+}
+
+// stvBallot tracks the weight, current tier and holder of a ballot parcel in a Gregory count.
+// A ballot that ranks several hopefuls equally is split into one parcel per hopeful.
+type stvBallot struct {
+	prefs  [][]int
+	weight float64
+	tier   int
+	holder int
+}
+
+// calculateGregory runs an STV count with weighted inclusive Gregory surplus transfers
+func calculateGregory(result *STVResult, preferences [][][]int, candidates []int, seats int) {
+	status := make(map[int]string)
+	for _, candidate := range candidates {
+		status[candidate] = stvHopeful
+	}
+
+	valid := 0
+	stvBallots := []*stvBallot{}
+	for _, prefs := range preferences {
+		if len(prefs) == 0 {
+			continue
+		}
+		valid++
+		stvBallots = append(stvBallots, splitParcel(prefs, 1, 0, status)...)
+	}
+
+	quota := float64(valid/(seats+1) + 1)
+	result.Quota = quota
+
+	// retained holds the votes kept by elected candidates whose surplus was transferred
+	retained := make(map[int]float64)
+	pending := []int{}
+	history := make(map[int][]float64)
+	exhausted := 0.0
+
+	for round := 1; ; round++ {
+		tallies := make(map[int]float64)
+		for _, candidate := range candidates {
+			if value, done := retained[candidate]; done {
+				tallies[candidate] = value
+			} else if status[candidate] != stvExcluded {
+				tallies[candidate] = 0
+			}
+		}
+		for _, b := range stvBallots {
+			if _, done := retained[b.holder]; !done {
+				tallies[b.holder] += b.weight
+			}
+		}
+		for candidate, votes := range tallies {
+			history[candidate] = append(history[candidate], votes)
+		}
+
+		stvRound := STVRound{
+			Round:     round,
+			Quota:     quota,
+			Tallies:   stvTallies(tallies, status, nil),
+			Exhausted: roundVotes(exhausted),
+		}
+
+		// Elect every hopeful that reached the quota, highest first
+		newlyElected := hopefulsAtLeast(tallies, status, quota)
+		for _, candidate := range newlyElected {
+			if len(result.Elected) == seats {
+				break
+			}
+			status[candidate] = stvElected
+			result.Elected = append(result.Elected, candidate)
+			stvRound.Elected = append(stvRound.Elected, candidate)
+			pending = append(pending, candidate)
+		}
+
+		hopefuls := candidatesWithStatus(status, stvHopeful)
+		if len(result.Elected) < seats && len(hopefuls)+len(result.Elected) <= seats {
+			sortByVotes(hopefuls, tallies)
+			for _, candidate := range hopefuls {
+				status[candidate] = stvElected
+				result.Elected = append(result.Elected, candidate)
+				stvRound.Elected = append(stvRound.Elected, candidate)
+			}
+		}
+
+		// Stop once every seat is filled or no hopefuls remain to fill it
+		if len(result.Elected) >= seats || len(candidatesWithStatus(status, stvHopeful)) == 0 {
+			result.Rounds = append(result.Rounds, stvRound)
+			break
+		}
+
+		// Transfer the largest pending surplus; surpluses of zero need no transfer
+		sortByVotes(pending, tallies)
+		for len(pending) > 0 && stvRound.Transfer == nil {
+			candidate := pending[0]
+			pending = pending[1:]
+
+			surplus := tallies[candidate] - quota
+			if surplus <= 0 {
+				retained[candidate] = tallies[candidate]
+				continue
+			}
+
+			transferValue := surplus / tallies[candidate]
+			retained[candidate] = quota
+			stvBallots, stvRound.Transfer = transferGregory(stvBallots, candidate, "surplus", transferValue, status, &exhausted)
+		}
+
+		// Otherwise exclude the hopeful with the fewest votes
+		if stvRound.Transfer == nil {
+			excluded, _ := stvLowestCandidate(tallies, status, history)
+			status[excluded] = stvExcluded
+			stvRound.Excluded = excluded
+			stvBallots, stvRound.Transfer = transferGregory(stvBallots, excluded, "exclusion", 1, status, &exhausted)
+		}
+
+		result.Rounds = append(result.Rounds, stvRound)
+	}
+
+	result.ExhaustedVotes = roundVotes(exhausted)
+}
+
+// transferGregory moves every parcel held by a candidate to its next hopeful preferences,
+// multiplying its weight by the transfer value, and returns the parcels left in the count
+func transferGregory(stvBallots []*stvBallot, from int, reason string, transferValue float64, status map[int]string, exhausted *float64) ([]*stvBallot, *STVTransfer) {
+	received := make(map[int]float64)
+	lost := 0.0
+	remaining := make([]*stvBallot, 0, len(stvBallots))
+
+	for _, b := range stvBallots {
+		if b.holder != from {
+			remaining = append(remaining, b)
+			continue
+		}
+
+		// Hopefuls ranked equally with the candidate come before later tiers
+		parcels := splitParcel(b.prefs, b.weight*transferValue, b.tier, status)
+		if len(parcels) == 0 {
+			lost += b.weight * transferValue
+			continue
+		}
+		for _, parcel := range parcels {
+			received[parcel.holder] += parcel.weight
+		}
+		remaining = append(remaining, parcels...)
+	}
+
+	*exhausted += lost
+
+	transfers := []STVTransferredVotes{}
+	for candidate, votes := range received {
+		transfers = append(transfers, STVTransferredVotes{ToCandidate: candidate, Votes: roundVotes(votes)})
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].ToCandidate < transfers[j].ToCandidate
+	})
+
+	return remaining, &STVTransfer{
+		FromCandidate: from,
+		Reason:        reason,
+		TransferValue: roundVotes(transferValue),
+		Transfers:     transfers,
+		Exhausted:     roundVotes(lost),
+	}
+}
+
+// splitParcel gives a ballot's weight to the hopefuls of its highest tier at or after start
+// that has any, split equally between them. It returns no parcels when the ballot exhausts.
+func splitParcel(prefs [][]int, weight float64, start int, status map[int]string) []*stvBallot {
+	for tier := start; tier < len(prefs); tier++ {
+		hopefuls := []int{}
+		for _, candidate := range prefs[tier] {
+			if status[candidate] == stvHopeful {
+				hopefuls = append(hopefuls, candidate)
+			}
+		}
+		if len(hopefuls) == 0 {
+			continue
+		}
+
+		parcels := make([]*stvBallot, len(hopefuls))
+		for i, candidate := range hopefuls {
+			parcels[i] = &stvBallot{
+				prefs:  prefs,
+				weight: weight / float64(len(hopefuls)),
+				tier:   tier,
+				holder: candidate,
+			}
+		}
+		return parcels
+	}
+	return nil
+}
+
+// calculateMeek runs an STV count using Meek's method
+func calculateMeek(result *STVResult, preferences [][][]int, candidates []int, seats int) {
+	status := make(map[int]string)
+	keepFactors := make(map[int]float64)
+	for _, candidate := range candidates {
+		status[candidate] = stvHopeful
+		keepFactors[candidate] = 1
+	}
+
+	history := make(map[int][]float64)
+
+	for round := 1; ; round++ {
+		var tallies map[int]float64
+		var excess, quota float64
+
+		// Adjust keep factors until every elected candidate holds the quota
+		for iteration := 0; iteration < meekMaxIterations; iteration++ {
+			tallies, excess = distributeMeek(preferences, keepFactors, candidates)
+			quota = (countNonEmpty(preferences) - excess) / float64(seats+1)
+
+			converged := true
+			for _, candidate := range result.Elected {
+				if math.Abs(tallies[candidate]-quota) > meekTolerance && tallies[candidate] > 0 {
+					converged = false
+					keepFactors[candidate] = math.Min(1, keepFactors[candidate]*quota/tallies[candidate])
+				}
+			}
+			if converged {
+				break
+			}
+		}
+
+		for candidate, votes := range tallies {
+			history[candidate] = append(history[candidate], votes)
+		}
+		if round == 1 {
+			result.Quota = roundVotes(quota)
+		}
+
+		stvRound := STVRound{
+			Round:     round,
+			Quota:     roundVotes(quota),
+			Tallies:   stvTallies(tallies, status, keepFactors),
+			Exhausted: roundVotes(excess),
+		}
+
+		for _, candidate := range hopefulsAbove(tallies, status, quota) {
+			if len(result.Elected) == seats {
+				break
+			}
+			status[candidate] = stvElected
+			result.Elected = append(result.Elected, candidate)
+			stvRound.Elected = append(stvRound.Elected, candidate)
+		}
+
+		hopefuls := candidatesWithStatus(status, stvHopeful)
+		if len(result.Elected) < seats && len(hopefuls)+len(result.Elected) <= seats {
+			sortByVotes(hopefuls, tallies)
+			for _, candidate := range hopefuls {
+				status[candidate] = stvElected
+				result.Elected = append(result.Elected, candidate)
+				stvRound.Elected = append(stvRound.Elected, candidate)
+			}
+		}
+
+		// Stop once every seat is filled or no hopefuls remain to fill it
+		if len(result.Elected) >= seats || len(candidatesWithStatus(status, stvHopeful)) == 0 {
+			result.Rounds = append(result.Rounds, stvRound)
+			result.ExhaustedVotes = roundVotes(excess)
+			break
+		}
+
+		if len(stvRound.Elected) == 0 {
+			excluded, _ := stvLowestCandidate(tallies, status, history)
+			status[excluded] = stvExcluded
+			keepFactors[excluded] = 0
+			stvRound.Excluded = excluded
+		}
+
+		result.Rounds = append(result.Rounds, stvRound)
+	}
+}
+
+// distributeMeek distributes every ballot according to the keep factors, splitting it
+// equally between the candidates of a tier, and returns each candidate's votes and the
+// votes left over on exhausted ballots
+func distributeMeek(preferences [][][]int, keepFactors map[int]float64, candidates []int) (map[int]float64, float64) {
+	tallies := make(map[int]float64)
+	for _, candidate := range candidates {
+		if keepFactors[candidate] > 0 {
+			tallies[candidate] = 0
+		}
+	}
+
+	excess := 0.0
+	for _, prefs := range preferences {
+		if len(prefs) == 0 {
+			continue
+		}
+
+		remaining := 1.0
+		for _, tier := range prefs {
+			active := []int{}
+			for _, candidate := range tier {
+				if keepFactors[candidate] > 0 {
+					active = append(active, candidate)
+				}
+			}
+			if len(active) == 0 {
+				continue
+			}
+
+			share := remaining / float64(len(active))
+			remaining = 0
+			for _, candidate := range active {
+				votes := share * keepFactors[candidate]
+				tallies[candidate] += votes
+				remaining += share - votes
+			}
+			if remaining <= 0 {
+				break
+			}
+		}
+		excess += remaining
+	}
+
+	return tallies, excess
+}
+
+// countNonEmpty counts the ballots that rank at least one candidate
+func countNonEmpty(preferences [][][]int) float64 {
+	count := 0
+	for _, prefs := range preferences {
+		if len(prefs) > 0 {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+// hopefulsAtLeast returns the hopeful candidates with at least the given votes, highest first
+func hopefulsAtLeast(tallies map[int]float64, status map[int]string, quota float64) []int {
+	candidates := []int{}
+	for candidate, votes := range tallies {
+		if status[candidate] == stvHopeful && votes >= quota {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sortByVotes(candidates, tallies)
+	return candidates
+}
+
+// hopefulsAbove returns the hopeful candidates with more than the given votes, highest first
+func hopefulsAbove(tallies map[int]float64, status map[int]string, quota float64) []int {
+	candidates := []int{}
+	for candidate, votes := range tallies {
+		if status[candidate] == stvHopeful && votes > quota+meekTolerance {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sortByVotes(candidates, tallies)
+	return candidates
+}
+
+// candidatesWithStatus returns the candidates with the given status in ID order
+func candidatesWithStatus(status map[int]string, want string) []int {
+	candidates := []int{}
+	for candidate, s := range status {
+		if s == want {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Ints(candidates)
+	return candidates
+}
+
+// sortByVotes orders candidates by votes (descending), then candidate ID
+func sortByVotes(candidates []int, tallies map[int]float64) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if tallies[candidates[i]] != tallies[candidates[j]] {
+			return tallies[candidates[i]] > tallies[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+}
+
+// stvLowestCandidate picks the hopeful to exclude and returns every hopeful tied for last place.
+// Ties are broken by the most recent earlier round in which the candidates differed,
+// then by excluding the highest candidate ID.
+func stvLowestCandidate(tallies map[int]float64, status map[int]string, history map[int][]float64) (int, []int) {
+	hopefuls := candidatesWithStatus(status, stvHopeful)
+
+	lowest := math.Inf(1)
+	for _, candidate := range hopefuls {
+		lowest = math.Min(lowest, tallies[candidate])
+	}
+
+	tied := []int{}
+	for _, candidate := range hopefuls {
+		if tallies[candidate] == lowest {
+			tied = append(tied, candidate)
+		}
+	}
+
+	candidates := make([]int, len(tied))
+	copy(candidates, tied)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := history[candidates[i]], history[candidates[j]]
+		for round := len(a) - 2; round >= 0 && round < len(b); round-- {
+			if a[round] != b[round] {
+				return a[round] < b[round]
+			}
+		}
+		return candidates[i] > candidates[j]
+	})
+
+	return candidates[0], tied
+}
+
+// stvTallies builds the reported tallies of a round in candidate order
+func stvTallies(tallies map[int]float64, status map[int]string, keepFactors map[int]float64) []STVCandidateTally {
+	reported := make([]STVCandidateTally, 0, len(tallies))
+	for candidate, votes := range tallies {
+		tally := STVCandidateTally{
+			CandidateID: candidate,
+			Votes:       roundVotes(votes),
+			Status:      status[candidate],
+		}
+		if keepFactors != nil {
+			keep := roundVotes(keepFactors[candidate])
+			tally.KeepFactor = &keep
+		}
+		reported = append(reported, tally)
+	}
+
+	sort.Slice(reported, func(i, j int) bool {
+		return reported[i].CandidateID < reported[j].CandidateID
+	})
+
+	return reported
+}
+
+// roundVotes rounds fractional vote values to six decimal places for reporting
+func roundVotes(votes float64) float64 {
+	return math.Round(votes*1e6) / 1e6
+}
@@ -0,0 +1,459 @@
+package ballot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Ballot types a tabulator can count, matching the election ballot types
+const (
+	TabulatesRanked   = "ranked"
+	TabulatesApproval = CardinalTypeApproval
+	TabulatesScore    = CardinalTypeScore
+)
+
+// Tabulator counts the ballots of an election with one counting method
+type Tabulator interface {
+	// Name is the method name the tabulator is registered under
+	Name() string
+	// BallotType is the type of ballot the tabulator counts
+	BallotType() string
+	// Description briefly describes the counting method
+	Description() string
+	// Options describes every option the tabulator accepts
+	Options() []TabulatorOption
+	// Tabulate counts the ballots with the given options
+	Tabulate(input *TabulationInput, options map[string]string) (interface{}, error)
+}
+
+// TabulatorOption describes an option accepted by a tabulator
+type TabulatorOption struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default"`
+	Values      []string `json:"values,omitempty"`
+	Description string   `json:"description"`
+}
+
+// TabulatorDescription describes a registered tabulator
+type TabulatorDescription struct {
+	Method      string            `json:"method"`
+	BallotType  string            `json:"ballot_type"`
+	Description string            `json:"description"`
+	Options     []TabulatorOption `json:"options"`
+}
+
+// StreamingTabulator is implemented by tabulators that can count ranked ballots streamed
+// from TabulationInput.RankedSource, or from the stored PairwiseTally, without loading them into memory
+type StreamingTabulator interface {
+	Tabulator
+	Streams() bool
+}
+
+// TabulationInput holds the ballots of an election for a tabulator.
+// Only the ballots matching the tabulator's ballot type are loaded; ranked ballots
+// are given as RankedSource and PairwiseTally instead of RankedBallots to a streaming tabulator.
+type TabulationInput struct {
+	ElectionID      string
+	MaxScore        int
+	RankedBallots   []RankedBallotWithRankings
+	RankedSource    RankedBallotSource
+	PairwiseTally   *PairwiseTally
+	CardinalBallots []CardinalBallotWithScores
+}
+
+// TabulationResult represents the outcome of counting an election with a registered method.
+// Official is true only for the election's official method with its official options.
+type TabulationResult struct {
+	ElectionID string            `json:"election_id"`
+	Method     string            `json:"method"`
+	Official   bool              `json:"official"`
+	Options    map[string]string `json:"options"`
+	Result     interface{}       `json:"result"`
+}
+
+// TabulatorRegistry holds tabulators by method name
+type TabulatorRegistry struct {
+	tabulators map[string]Tabulator
+	names      []string
+}
+
+// NewTabulatorRegistry creates an empty tabulator registry
+func NewTabulatorRegistry() *TabulatorRegistry {
+	return &TabulatorRegistry{tabulators: make(map[string]Tabulator)}
+}
+
+// Register adds a tabulator under its method name.
+// The first tabulator registered for a ballot type is that type's default method.
+func (r *TabulatorRegistry) Register(t Tabulator) error {
+	if t.Name() == "" {
+		return fmt.Errorf("tabulator method name is required")
+	}
+	if _, exists := r.tabulators[t.Name()]; exists {
+		return fmt.Errorf("tabulator %s is already registered", t.Name())
+	}
+
+	r.tabulators[t.Name()] = t
+	r.names = append(r.names, t.Name())
+	return nil
+}
+
+// Get returns the tabulator registered under a method name
+func (r *TabulatorRegistry) Get(method string) (Tabulator, error) {
+	t, ok := r.tabulators[method]
+	if !ok {
+		return nil, fmt.Errorf("invalid method: %s is not a registered counting method", method)
+	}
+	return t, nil
+}
+
+// DefaultMethod returns the default method for a ballot type, or "" if no tabulator counts it
+func (r *TabulatorRegistry) DefaultMethod(ballotType string) string {
+	for _, name := range r.names {
+		if r.tabulators[name].BallotType() == ballotType {
+			return name
+		}
+	}
+	return ""
+}
+
+// Describe lists every registered tabulator in registration order
+func (r *TabulatorRegistry) Describe() []TabulatorDescription {
+	descriptions := make([]TabulatorDescription, 0, len(r.names))
+	for _, name := range r.names {
+		t := r.tabulators[name]
+		descriptions = append(descriptions, TabulatorDescription{
+			Method:      name,
+			BallotType:  t.BallotType(),
+			Description: t.Description(),
+			Options:     t.Options(),
+		})
+	}
+	return descriptions
+}
+
+// ResolveMethod verifies that a method counts the given ballot type and accepts the options,
+// and returns the options with every unset option filled in with its default.
+// An empty method resolves to the ballot type's default method.
+func (r *TabulatorRegistry) ResolveMethod(ballotType, method string, options map[string]string) (string, map[string]string, error) {
+	if method == "" {
+		if len(options) > 0 {
+			return "", nil, fmt.Errorf("invalid method options: a method is required when options are given")
+		}
+		method = r.DefaultMethod(ballotType)
+		if method == "" {
+			return "", map[string]string{}, nil
+		}
+	}
+
+	t, err := r.Get(method)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if t.BallotType() != ballotType {
+		return "", nil, fmt.Errorf("invalid method: %s counts %s ballots, not %s ballots", method, t.BallotType(), ballotType)
+	}
+
+	if err := checkOptions(t, options); err != nil {
+		return "", nil, err
+	}
+
+	resolved := make(map[string]string)
+	for _, option := range t.Options() {
+		resolved[option.Name] = option.Default
+		if value := options[option.Name]; value != "" {
+			resolved[option.Name] = value
+		}
+	}
+
+	return method, resolved, nil
+}
+
+// checkOptions rejects options a tabulator does not declare and values outside an option's declared values
+func checkOptions(t Tabulator, options map[string]string) error {
+	declared := make(map[string]TabulatorOption)
+	for _, option := range t.Options() {
+		declared[option.Name] = option
+	}
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		option, ok := declared[name]
+		if !ok {
+			return fmt.Errorf("invalid option %s for method %s", name, t.Name())
+		}
+		if err := option.check(options[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check verifies that a value has the option's type and is one of its declared values
+func (o TabulatorOption) check(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch o.Type {
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid %s: must be an integer", o.Name)
+		}
+	case "float":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("invalid %s: must be a number", o.Name)
+		}
+	}
+
+	if len(o.Values) == 0 {
+		return nil
+	}
+	for _, allowed := range o.Values {
+		if value == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s: must be one of %v", o.Name, o.Values)
+}
+
+// methodTabulator adapts a counting function to the Tabulator interface
+type methodTabulator struct {
+	name        string
+	ballotType  string
+	description string
+	options     []TabulatorOption
+	streams     bool
+	tabulate    func(input *TabulationInput, options map[string]string) (interface{}, error)
+}
+
+func (t *methodTabulator) Name() string               { return t.name }
+func (t *methodTabulator) BallotType() string         { return t.ballotType }
+func (t *methodTabulator) Description() string        { return t.description }
+func (t *methodTabulator) Options() []TabulatorOption { return t.options }
+func (t *methodTabulator) Streams() bool              { return t.streams }
+
+// Tabulate checks the option names and runs the counting function
+func (t *methodTabulator) Tabulate(input *TabulationInput, options map[string]string) (interface{}, error) {
+	if err := checkOptions(t, options); err != nil {
+		return nil, err
+	}
+	return t.tabulate(input, options)
+}
+
+// DefaultTabulators returns a registry holding every built-in counting method.
+// Schulze, approval and score are the defaults for ranked, approval and score ballots.
+func DefaultTabulators() *TabulatorRegistry {
+	registry := NewTabulatorRegistry()
+	for _, t := range builtinTabulators() {
+		if err := registry.Register(t); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// builtinTabulators lists the built-in counting methods in registration order
+func builtinTabulators() []Tabulator {
+	return []Tabulator{
+		&methodTabulator{
+			name:        "schulze",
+			ballotType:  TabulatesRanked,
+			description: "Schulze beatpath method with a seeded random-ballot tie-breaker",
+			options: []TabulatorOption{
+				{Name: "strength", Type: "string", Default: SchulzeWinningVotes, Values: []string{SchulzeWinningVotes, SchulzeMargins, SchulzeRatio}, Description: "how the strength of a pairwise defeat is measured"},
+				{Name: "unranked", Type: "string", Default: UnrankedBelowRanked, Values: []string{UnrankedBelowRanked, UnrankedIgnore}, Description: "how candidates a ballot leaves unranked are counted"},
+				{Name: "equal_ranking", Type: "string", Default: EqualRankingIgnore, Values: []string{EqualRankingIgnore, EqualRankingCountBoth}, Description: "how equally ranked candidates are counted"},
+			},
+			streams: true,
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				opts := SchulzeOptions{
+					Strength: options["strength"],
+					PairwiseOptions: PairwiseOptions{
+						Unranked:     options["unranked"],
+						EqualRanking: options["equal_ranking"],
+					},
+				}
+
+				var result *SchulzeResult
+				var err error
+				switch {
+				case input.PairwiseTally != nil:
+					result, err = CalculateSchulzeFromTally(input.PairwiseTally, input.RankedSource, opts)
+				case input.RankedSource != nil:
+					result, err = CalculateSchulzeStream(input.RankedSource, opts)
+				default:
+					result, err = CalculateSchulze(input.RankedBallots, opts)
+				}
+				if err != nil {
+					return nil, err
+				}
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "irv",
+			ballotType:  TabulatesRanked,
+			description: "instant-runoff voting with round-by-round counts",
+			options:     []TabulatorOption{},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result := CalculateIRV(input.RankedBallots)
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "stv",
+			ballotType:  TabulatesRanked,
+			description: "single transferable vote with a Droop quota",
+			options: []TabulatorOption{
+				{Name: "seats", Type: "int", Default: "1", Description: "number of seats to fill"},
+				{Name: "transfer_rule", Type: "string", Default: STVTransferGregory, Values: []string{STVTransferGregory, STVTransferMeek}, Description: "how surpluses are transferred"},
+			},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				seats, err := intOption(options, "seats", 1)
+				if err != nil {
+					return nil, err
+				}
+				result, err := CalculateSTV(input.RankedBallots, STVOptions{Seats: seats, TransferRule: options["transfer_rule"]})
+				if err != nil {
+					return nil, err
+				}
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "ranked_pairs",
+			ballotType:  TabulatesRanked,
+			description: "Ranked Pairs (Tideman) with locked and skipped edges",
+			options:     []TabulatorOption{},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result := CalculateRankedPairs(input.RankedBallots)
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "minimax",
+			ballotType:  TabulatesRanked,
+			description: "Minimax (Simpson-Kramer) by worst pairwise result",
+			options: []TabulatorOption{
+				{Name: "variant", Type: "string", Default: MinimaxWinningVotes, Values: []string{MinimaxWinningVotes, MinimaxMargins, MinimaxPairwiseOpposition}, Description: "how a pairwise result is scored"},
+			},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result, err := CalculateMinimax(input.RankedBallots, options["variant"])
+				if err != nil {
+					return nil, err
+				}
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "borda",
+			ballotType:  TabulatesRanked,
+			description: "Borda count",
+			options: []TabulatorOption{
+				{Name: "scheme", Type: "string", Default: BordaStandard, Values: []string{BordaStandard, BordaDowdall, BordaModified}, Description: "points given to each ranking position"},
+			},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result, err := CalculateBorda(input.RankedBallots, options["scheme"])
+				if err != nil {
+					return nil, err
+				}
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "copeland",
+			ballotType:  TabulatesRanked,
+			description: "Copeland method by pairwise wins",
+			options: []TabulatorOption{
+				{Name: "tie_score", Type: "float", Default: "0.5", Description: "points a pairwise tie is worth, between 0 and 1"},
+			},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				tieScore, err := floatOption(options, "tie_score", 0.5)
+				if err != nil {
+					return nil, err
+				}
+				result, err := CalculateCopeland(input.RankedBallots, tieScore)
+				if err != nil {
+					return nil, err
+				}
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "approval",
+			ballotType:  TabulatesApproval,
+			description: "approval voting by number of approvals",
+			options:     []TabulatorOption{},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result := CalculateApproval(input.CardinalBallots)
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "score",
+			ballotType:  TabulatesScore,
+			description: "range/score voting by total score",
+			options:     []TabulatorOption{},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result := CalculateScore(input.CardinalBallots, input.MaxScore)
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+		&methodTabulator{
+			name:        "star",
+			ballotType:  TabulatesScore,
+			description: "STAR voting (score then automatic runoff)",
+			options:     []TabulatorOption{},
+			tabulate: func(input *TabulationInput, options map[string]string) (interface{}, error) {
+				result := CalculateSTAR(input.CardinalBallots, input.MaxScore)
+				result.ElectionID = input.ElectionID
+				return result, nil
+			},
+		},
+	}
+}
+
+// intOption parses an integer option, returning the default when it is not set
+func intOption(options map[string]string, name string, defaultValue int) (int, error) {
+	value, ok := options[name]
+	if !ok || value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: must be an integer", name)
+	}
+	return parsed, nil
+}
+
+// floatOption parses a number option, returning the default when it is not set
+func floatOption(options map[string]string, name string, defaultValue float64) (float64, error) {
+	value, ok := options[name]
+	if !ok || value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: must be a number", name)
+	}
+	return parsed, nil
+}
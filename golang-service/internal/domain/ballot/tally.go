@@ -0,0 +1,289 @@
+package ballot
+
+import "sort"
+
+// RankedBallotSource streams the ranked ballots of an election to yield in ballot ID order,
+// stopping at the first error yield returns. Every call starts a new pass over the ballots.
+// The ballot passed to yield is only valid until yield returns.
+type RankedBallotSource func(yield func(ballot RankedBallotWithRankings) error) error
+
+// CandidateTally is the number of ballots ranking a candidate
+type CandidateTally struct {
+	CandidateID int `json:"candidate_id" db:"candidate_id"`
+	Ranked      int `json:"ranked" db:"ranked"`
+}
+
+// PairTally counts the ballots ranking both candidates of a pair, with CandidateA < CandidateB
+type PairTally struct {
+	CandidateA int `json:"candidate_a" db:"candidate_a"`
+	CandidateB int `json:"candidate_b" db:"candidate_b"`
+	AOverB     int `json:"a_over_b" db:"a_over_b"`
+	BOverA     int `json:"b_over_a" db:"b_over_a"`
+	Equal      int `json:"equal" db:"equal"`
+}
+
+// TallyDrift is a count that differs between a stored pairwise tally and one rebuilt from the ballots.
+// CandidateB is 0 for the ranked count of CandidateA.
+type TallyDrift struct {
+	CandidateA int    `json:"candidate_a"`
+	CandidateB int    `json:"candidate_b,omitempty"`
+	Count      string `json:"count"`
+	Stored     int    `json:"stored"`
+	Rebuilt    int    `json:"rebuilt"`
+}
+
+// TallyVerification reports whether an election's stored pairwise tally matches its ballots
+type TallyVerification struct {
+	ElectionID string       `json:"election_id"`
+	Ballots    int          `json:"ballots"`
+	Consistent bool         `json:"consistent"`
+	Drift      []TallyDrift `json:"drift"`
+}
+
+// PairwiseTally accumulates the pairwise preferences of ranked ballots one ballot at a time.
+// It only holds per-candidate and per-pair counts, so its memory depends on the number of
+// candidates rather than ballots, and it can produce a matrix for any PairwiseOptions.
+type PairwiseTally struct {
+	index      map[int]int
+	candidates []int
+
+	// ranked[i] counts the ballots ranking candidate i
+	ranked []int
+	// preferred[i][j] counts the ballots ranking both, candidate i strictly above candidate j
+	preferred [][]int
+	// equal[i][j] counts the ballots ranking both candidates equally
+	equal [][]int
+
+	positions []int
+}
+
+// NewPairwiseTally creates an empty pairwise tally
+func NewPairwiseTally() *PairwiseTally {
+	return &PairwiseTally{index: make(map[int]int)}
+}
+
+// Add counts one ballot's rankings. Only pairs the ballot ranks are compared, so the
+// cost depends on the number of candidates ranked rather than on the candidate count.
+func (t *PairwiseTally) Add(rankings []BallotRanking) {
+	t.count(rankings, 1)
+}
+
+// Remove takes back the counts of a ballot that was added, such as a superseded ballot.
+// Counts of a tally that only had ballots removed are negative.
+func (t *PairwiseTally) Remove(rankings []BallotRanking) {
+	t.count(rankings, -1)
+}
+
+// count adds delta to every count the ballot's rankings contribute to
+func (t *PairwiseTally) count(rankings []BallotRanking, delta int) {
+	t.positions = t.positions[:0]
+	for _, ranking := range rankings {
+		i := t.candidateIndex(ranking.CandidateID)
+		t.ranked[i] += delta
+		t.positions = append(t.positions, i)
+	}
+
+	for a := range rankings {
+		i := t.positions[a]
+		for b := a + 1; b < len(rankings); b++ {
+			j := t.positions[b]
+			switch rankA, rankB := rankings[a].RankPosition, rankings[b].RankPosition; {
+			case rankA < rankB:
+				t.preferred[i][j] += delta
+			case rankA > rankB:
+				t.preferred[j][i] += delta
+			default:
+				t.equal[i][j] += delta
+				t.equal[j][i] += delta
+			}
+		}
+	}
+}
+
+// Matrix builds the pairwise matrix over every candidate ranked on any counted ballot,
+// applying the given rules to unranked and equally ranked candidates. The options must be valid.
+//
+// A ballot ranking i but not j counts for i only when unranked candidates are placed below
+// ranked ones; there are ranked[i] minus the ballots ranking both such ballots.
+func (t *PairwiseTally) Matrix(opts PairwiseOptions) *PairwiseMatrix {
+	candidates := make([]int, len(t.candidates))
+	copy(candidates, t.candidates)
+	sort.Ints(candidates)
+
+	n := len(candidates)
+	d := make([][]int, n)
+	for a, candidateA := range candidates {
+		d[a] = make([]int, n)
+		i := t.index[candidateA]
+		for b, candidateB := range candidates {
+			if a == b {
+				continue
+			}
+			j := t.index[candidateB]
+
+			count := t.preferred[i][j]
+			if opts.EqualRanking == EqualRankingCountBoth {
+				count += t.equal[i][j]
+			}
+			if opts.Unranked == UnrankedBelowRanked {
+				count += t.ranked[i] - t.preferred[i][j] - t.preferred[j][i] - t.equal[i][j]
+			}
+			d[a][b] = count
+		}
+	}
+
+	return &PairwiseMatrix{
+		Candidates: candidates,
+		D:          d,
+	}
+}
+
+// candidateIndex returns the tally index of a candidate, adding the candidate on first sight.
+// Earlier ballots did not rank a new candidate, so its counts start at zero.
+func (t *PairwiseTally) candidateIndex(candidateID int) int {
+	if i, ok := t.index[candidateID]; ok {
+		return i
+	}
+
+	i := len(t.candidates)
+	t.index[candidateID] = i
+	t.candidates = append(t.candidates, candidateID)
+	t.ranked = append(t.ranked, 0)
+
+	for row := range t.preferred {
+		t.preferred[row] = append(t.preferred[row], 0)
+		t.equal[row] = append(t.equal[row], 0)
+	}
+	t.preferred = append(t.preferred, make([]int, i+1))
+	t.equal = append(t.equal, make([]int, i+1))
+
+	return i
+}
+
+// RestorePairwiseTally rebuilds a tally from stored counts
+func RestorePairwiseTally(candidates []CandidateTally, pairs []PairTally) *PairwiseTally {
+	t := NewPairwiseTally()
+	for _, candidate := range candidates {
+		t.ranked[t.candidateIndex(candidate.CandidateID)] += candidate.Ranked
+	}
+	for _, pair := range pairs {
+		i := t.candidateIndex(pair.CandidateA)
+		j := t.candidateIndex(pair.CandidateB)
+		t.preferred[i][j] += pair.AOverB
+		t.preferred[j][i] += pair.BOverA
+		t.equal[i][j] += pair.Equal
+		t.equal[j][i] += pair.Equal
+	}
+	return t
+}
+
+// BallotTally returns the counts one ballot adds to a pairwise tally
+func BallotTally(rankings []BallotRanking) ([]CandidateTally, []PairTally) {
+	return ReplacementTally(nil, rankings)
+}
+
+// ReplacementTally returns the counts by which a pairwise tally changes when a ballot is added
+// and the superseded ballots it replaces are removed
+func ReplacementTally(superseded [][]BallotRanking, rankings []BallotRanking) ([]CandidateTally, []PairTally) {
+	t := NewPairwiseTally()
+	for _, supersededRankings := range superseded {
+		t.Remove(supersededRankings)
+	}
+	t.Add(rankings)
+	return t.Counts()
+}
+
+// Counts returns the tally's counts in candidate order, omitting pairs no ballot ranks together
+func (t *PairwiseTally) Counts() ([]CandidateTally, []PairTally) {
+	candidates := make([]int, len(t.candidates))
+	copy(candidates, t.candidates)
+	sort.Ints(candidates)
+
+	candidateTallies := make([]CandidateTally, 0, len(candidates))
+	pairTallies := []PairTally{}
+	for a, candidateA := range candidates {
+		i := t.index[candidateA]
+		candidateTallies = append(candidateTallies, CandidateTally{CandidateID: candidateA, Ranked: t.ranked[i]})
+
+		for _, candidateB := range candidates[a+1:] {
+			j := t.index[candidateB]
+			pair := PairTally{
+				CandidateA: candidateA,
+				CandidateB: candidateB,
+				AOverB:     t.preferred[i][j],
+				BOverA:     t.preferred[j][i],
+				Equal:      t.equal[i][j],
+			}
+			if pair.AOverB != 0 || pair.BOverA != 0 || pair.Equal != 0 {
+				pairTallies = append(pairTallies, pair)
+			}
+		}
+	}
+
+	return candidateTallies, pairTallies
+}
+
+// CompareTallies lists every count that differs between a stored tally and a rebuilt one
+func CompareTallies(stored, rebuilt *PairwiseTally) []TallyDrift {
+	storedCandidates, storedPairs := stored.Counts()
+	rebuiltCandidates, rebuiltPairs := rebuilt.Counts()
+
+	ranked := make(map[int][2]int)
+	for _, c := range storedCandidates {
+		counts := ranked[c.CandidateID]
+		counts[0] = c.Ranked
+		ranked[c.CandidateID] = counts
+	}
+	for _, c := range rebuiltCandidates {
+		counts := ranked[c.CandidateID]
+		counts[1] = c.Ranked
+		ranked[c.CandidateID] = counts
+	}
+
+	type pairKey struct{ a, b int }
+	pairs := make(map[pairKey][2]PairTally)
+	for _, p := range storedPairs {
+		counts := pairs[pairKey{p.CandidateA, p.CandidateB}]
+		counts[0] = p
+		pairs[pairKey{p.CandidateA, p.CandidateB}] = counts
+	}
+	for _, p := range rebuiltPairs {
+		counts := pairs[pairKey{p.CandidateA, p.CandidateB}]
+		counts[1] = p
+		pairs[pairKey{p.CandidateA, p.CandidateB}] = counts
+	}
+
+	drift := []TallyDrift{}
+	for candidate, counts := range ranked {
+		if counts[0] != counts[1] {
+			drift = append(drift, TallyDrift{CandidateA: candidate, Count: "ranked", Stored: counts[0], Rebuilt: counts[1]})
+		}
+	}
+	for key, counts := range pairs {
+		storedPair, rebuiltPair := counts[0], counts[1]
+		for _, count := range []struct {
+			name            string
+			stored, rebuilt int
+		}{
+			{"a_over_b", storedPair.AOverB, rebuiltPair.AOverB},
+			{"b_over_a", storedPair.BOverA, rebuiltPair.BOverA},
+			{"equal", storedPair.Equal, rebuiltPair.Equal},
+		} {
+			if count.stored != count.rebuilt {
+				drift = append(drift, TallyDrift{CandidateA: key.a, CandidateB: key.b, Count: count.name, Stored: count.stored, Rebuilt: count.rebuilt})
+			}
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].CandidateA != drift[j].CandidateA {
+			return drift[i].CandidateA < drift[j].CandidateA
+		}
+		if drift[i].CandidateB != drift[j].CandidateB {
+			return drift[i].CandidateB < drift[j].CandidateB
+		}
+		return drift[i].Count < drift[j].Count
+	})
+
+	return drift
+}
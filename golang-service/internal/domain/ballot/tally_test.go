@@ -0,0 +1,206 @@
+package ballot
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// benchmarkSizes are the election sizes the tally benchmarks run at
+var benchmarkSizes = []struct {
+	ballots    int
+	candidates int
+}{
+	{ballots: 10000, candidates: 5},
+	{ballots: 100000, candidates: 10},
+	{ballots: 100000, candidates: 30},
+}
+
+// syntheticElection generates ranked ballots deterministically, ranking a random
+// subset of the candidates with occasional ties, in ballot ID order
+type syntheticElection struct {
+	ballots    int
+	candidates int
+}
+
+// each yields every ballot, reusing one rankings slice as the repository does
+func (e syntheticElection) each(yield func(RankedBallotWithRankings) error) error {
+	rng := rand.New(rand.NewPCG(uint64(e.ballots), uint64(e.candidates)))
+	order := make([]int, e.candidates)
+	current := RankedBallotWithRankings{Ballot: RankedBallot{ElectionID: "bench"}}
+
+	for n := 0; n < e.ballots; n++ {
+		current.Ballot.BallotID = fmt.Sprintf("rb_%09d", n)
+		current.Rankings = current.Rankings[:0]
+
+		for i := range order {
+			order[i] = i + 1
+		}
+		rng.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+
+		ranked := 1 + rng.IntN(e.candidates)
+		position := 0
+		for i := 0; i < ranked; i++ {
+			if i == 0 || rng.IntN(10) > 0 {
+				position++
+			}
+			current.Rankings = append(current.Rankings, BallotRanking{
+				BallotID:     current.Ballot.BallotID,
+				CandidateID:  order[i],
+				RankPosition: position,
+			})
+		}
+
+		if err := yield(current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load materializes every ballot, as RankedBallotPostgresRepository.GetByElectionID does
+func (e syntheticElection) load() []RankedBallotWithRankings {
+	ballots := make([]RankedBallotWithRankings, 0)
+	e.each(func(ballot RankedBallotWithRankings) error {
+		rankings := make([]BallotRanking, len(ballot.Rankings))
+		copy(rankings, ballot.Rankings)
+		ballots = append(ballots, RankedBallotWithRankings{Ballot: ballot.Ballot, Rankings: rankings})
+		return nil
+	})
+	return ballots
+}
+
+// mapPairwiseMatrix is the map-based counting PairwiseTally replaced, comparing
+// every pair of candidates on every ballot; it is kept as the benchmark baseline
+func mapPairwiseMatrix(ballots []RankedBallotWithRankings, opts PairwiseOptions) *PairwiseMatrix {
+	candidateSet := make(map[int]bool)
+	for _, ballot := range ballots {
+		for _, ranking := range ballot.Rankings {
+			candidateSet[ranking.CandidateID] = true
+		}
+	}
+
+	candidates := make([]int, 0, len(candidateSet))
+	for candidate := range candidateSet {
+		candidates = append(candidates, candidate)
+	}
+	sort.Ints(candidates)
+
+	d := make([][]int, len(candidates))
+	for i := range d {
+		d[i] = make([]int, len(candidates))
+	}
+
+	for _, ballot := range ballots {
+		rankMap := make(map[int]int)
+		for _, ranking := range ballot.Rankings {
+			rankMap[ranking.CandidateID] = ranking.RankPosition
+		}
+
+		for i, candidateA := range candidates {
+			for j, candidateB := range candidates {
+				if i == j {
+					continue
+				}
+				rankA, hasA := rankMap[candidateA]
+				rankB, hasB := rankMap[candidateB]
+
+				switch {
+				case hasA && hasB && rankA < rankB:
+					d[i][j]++
+				case hasA && hasB && rankA == rankB:
+					if opts.EqualRanking == EqualRankingCountBoth {
+						d[i][j]++
+					}
+				case hasA && !hasB:
+					if opts.Unranked == UnrankedBelowRanked {
+						d[i][j]++
+					}
+				}
+			}
+		}
+	}
+
+	return &PairwiseMatrix{Candidates: candidates, D: d}
+}
+
+func TestPairwiseTallyMatchesMapCounting(t *testing.T) {
+	ballots := syntheticElection{ballots: 2000, candidates: 8}.load()
+
+	for _, unranked := range []string{UnrankedBelowRanked, UnrankedIgnore} {
+		for _, equal := range []string{EqualRankingIgnore, EqualRankingCountBoth} {
+			opts := PairwiseOptions{Unranked: unranked, EqualRanking: equal}
+			if got, want := CountPairwiseMatrix(ballots, opts), mapPairwiseMatrix(ballots, opts); !reflect.DeepEqual(got, want) {
+				t.Errorf("matrix with %+v = %v, want %v", opts, got.D, want.D)
+			}
+		}
+	}
+}
+
+func TestCalculateSchulzeStreamMatchesInMemory(t *testing.T) {
+	election := syntheticElection{ballots: 500, candidates: 6}
+
+	want, err := CalculateSchulze(election.load(), SchulzeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := CalculateSchulzeStream(election.each, SchulzeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed result = %+v, want %+v", got, want)
+	}
+}
+
+func BenchmarkPairwiseMatrix(b *testing.B) {
+	opts := PairwiseOptions{Unranked: UnrankedBelowRanked, EqualRanking: EqualRankingIgnore}
+
+	for _, size := range benchmarkSizes {
+		ballots := syntheticElection(size).load()
+		name := fmt.Sprintf("ballots=%d/candidates=%d", size.ballots, size.candidates)
+
+		b.Run(name+"/map", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mapPairwiseMatrix(ballots, opts)
+			}
+		})
+		b.Run(name+"/tally", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CountPairwiseMatrix(ballots, opts)
+			}
+		})
+	}
+}
+
+func BenchmarkSchulze(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		election := syntheticElection(size)
+		name := fmt.Sprintf("ballots=%d/candidates=%d", size.ballots, size.candidates)
+
+		// Loading every ballot first, then counting, as results were computed before streaming
+		b.Run(name+"/in_memory", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := CalculateSchulze(election.load(), SchulzeOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/streamed", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := CalculateSchulzeStream(election.each, SchulzeOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package ballot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"math/rand/v2"
+	"sort"
+)
+
+// RandomBallotTieBreakRule documents how ties left by a tabulation are broken
+const RandomBallotTieBreakRule = "random ballot: ballots are drawn in a pseudo-random order seeded with " +
+	"SHA-256(election_id, sorted ballot_ids); each drawn ballot orders candidates it ranks differently " +
+	"that are still tied, and any ties left after every ballot are broken by a seeded shuffle"
+
+// TieBreaker reports the tie-breaking ranking used by a tabulation
+type TieBreaker struct {
+	Rule  string `json:"rule"`
+	Seed  string `json:"seed"`
+	Order []int  `json:"order"`
+}
+
+// tieBreakBatch is the number of ballots drawn at once when ballots are streamed
+const tieBreakBatch = 16
+
+// NewRandomBallotTieBreaker builds a tie-breaking ranking of candidates from the ballots.
+//
+// The seed is derived from the election ID and every ballot ID, so anyone holding the
+// published ballots can reproduce the ranking. The ChaCha8 generator keeps the draw
+// stable across Go versions.
+func NewRandomBallotTieBreaker(ballots []RankedBallotWithRankings, candidates []int) *TieBreaker {
+	sorted := make([]RankedBallotWithRankings, len(ballots))
+	copy(sorted, ballots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Ballot.BallotID < sorted[j].Ballot.BallotID
+	})
+
+	seed := newTieBreakSeed()
+	for i := range sorted {
+		seed.add(&sorted[i].Ballot)
+	}
+
+	tieBreaker, _ := newTieBreaker(seed.sum(), len(sorted), candidates, func(positions []int) ([]map[int]int, error) {
+		rankMaps := make([]map[int]int, len(positions))
+		for i, position := range positions {
+			rankMaps[i] = rankMap(sorted[position].Rankings)
+		}
+		return rankMaps, nil
+	})
+	return tieBreaker
+}
+
+// NewStreamedRandomBallotTieBreaker builds the same tie-breaking ranking as NewRandomBallotTieBreaker
+// from ballots streamed in ballot ID order. Only the drawn ballots are held in memory; each batch
+// of draws takes one more pass over the source.
+func NewStreamedRandomBallotTieBreaker(source RankedBallotSource, candidates []int) (*TieBreaker, error) {
+	seed := newTieBreakSeed()
+	count := 0
+	err := source(func(ballot RankedBallotWithRankings) error {
+		seed.add(&ballot.Ballot)
+		count++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTieBreaker(seed.sum(), count, candidates, func(positions []int) ([]map[int]int, error) {
+		wanted := make(map[int]int, len(positions))
+		for i, position := range positions {
+			wanted[position] = i
+		}
+
+		rankMaps := make([]map[int]int, len(positions))
+		position := 0
+		err := source(func(ballot RankedBallotWithRankings) error {
+			if i, ok := wanted[position]; ok {
+				rankMaps[i] = rankMap(ballot.Rankings)
+			}
+			position++
+			return nil
+		})
+		return rankMaps, err
+	})
+}
+
+// newTieBreaker draws ballots until every candidate is separated or the ballots run out,
+// then shuffles the candidates still tied. fetch returns the rank positions of the ballots
+// at the given positions in ballot ID order.
+func newTieBreaker(seed [32]byte, count int, candidates []int, fetch func(positions []int) ([]map[int]int, error)) (*TieBreaker, error) {
+	rng := rand.New(rand.NewChaCha8(seed))
+	draw := &ballotDraw{rng: rng, n: count, swapped: make(map[int]int)}
+
+	// Start with every candidate tied, in ID order
+	initial := make([]int, len(candidates))
+	copy(initial, candidates)
+	sort.Ints(initial)
+	groups := [][]int{initial}
+
+	for !allSingletons(groups) {
+		positions := draw.take(tieBreakBatch)
+		if len(positions) == 0 {
+			break
+		}
+
+		rankMaps, err := fetch(positions)
+		if err != nil {
+			return nil, err
+		}
+		for _, ranks := range rankMaps {
+			if allSingletons(groups) {
+				break
+			}
+			groups = refineGroups(groups, ranks)
+		}
+	}
+
+	order := []int{}
+	for _, group := range groups {
+		rng.Shuffle(len(group), func(i, j int) {
+			group[i], group[j] = group[j], group[i]
+		})
+		order = append(order, group...)
+	}
+
+	return &TieBreaker{
+		Rule:  RandomBallotTieBreakRule,
+		Seed:  hex.EncodeToString(seed[:]),
+		Order: order,
+	}, nil
+}
+
+// tieBreakSeed hashes the election ID followed by every ballot ID in ballot ID order
+type tieBreakSeed struct {
+	hash    hash.Hash
+	started bool
+}
+
+// newTieBreakSeed starts a tie-breaking seed
+func newTieBreakSeed() *tieBreakSeed {
+	return &tieBreakSeed{hash: sha256.New()}
+}
+
+// add hashes the next ballot; ballots must be added in ballot ID order
+func (s *tieBreakSeed) add(ballot *RankedBallot) {
+	if !s.started {
+		s.hash.Write([]byte(ballot.ElectionID))
+		s.started = true
+	}
+	s.hash.Write([]byte{0})
+	s.hash.Write([]byte(ballot.BallotID))
+}
+
+// sum returns the seed
+func (s *tieBreakSeed) sum() [32]byte {
+	var seed [32]byte
+	copy(seed[:], s.hash.Sum(nil))
+	return seed
+}
+
+// ballotDraw draws ballot positions without replacement in a seeded pseudo-random order.
+// It runs a Fisher-Yates shuffle lazily, remembering only the positions swapped so far,
+// so drawing a few ballots from millions needs no per-ballot memory.
+type ballotDraw struct {
+	rng     *rand.Rand
+	n       int
+	drawn   int
+	swapped map[int]int
+}
+
+// take draws up to limit more positions
+func (d *ballotDraw) take(limit int) []int {
+	positions := []int{}
+	for len(positions) < limit && d.drawn < d.n {
+		j := d.drawn + d.rng.IntN(d.n-d.drawn)
+		positions = append(positions, d.at(j))
+		d.swapped[j] = d.at(d.drawn)
+		delete(d.swapped, d.drawn)
+		d.drawn++
+	}
+	return positions
+}
+
+// at returns the position currently held at index i of the lazy shuffle
+func (d *ballotDraw) at(i int) int {
+	if position, ok := d.swapped[i]; ok {
+		return position
+	}
+	return i
+}
+
+// rankMap maps each ranked candidate to its rank position
+func rankMap(rankings []BallotRanking) map[int]int {
+	ranks := make(map[int]int, len(rankings))
+	for _, ranking := range rankings {
+		ranks[ranking.CandidateID] = ranking.RankPosition
+	}
+	return ranks
+}
+
+// Positions returns each candidate's position in the tie-breaking ranking
+func (t *TieBreaker) Positions() map[int]int {
+	positions := make(map[int]int, len(t.Order))
+	for i, candidate := range t.Order {
+		positions[candidate] = i
+	}
+	return positions
+}
+
+// refineGroups splits every tied group by a ballot's rank positions.
+// Unranked candidates are placed after ranked ones and stay tied with each other.
+func refineGroups(groups [][]int, rankMap map[int]int) [][]int {
+	refined := [][]int{}
+
+	for _, group := range groups {
+		if len(group) == 1 {
+			refined = append(refined, group)
+			continue
+		}
+
+		byRank := make(map[int][]int)
+		ranks := []int{}
+		unranked := []int{}
+		for _, candidate := range group {
+			rank, ok := rankMap[candidate]
+			if !ok {
+				unranked = append(unranked, candidate)
+				continue
+			}
+			if _, seen := byRank[rank]; !seen {
+				ranks = append(ranks, rank)
+			}
+			byRank[rank] = append(byRank[rank], candidate)
+		}
+		sort.Ints(ranks)
+
+		for _, rank := range ranks {
+			refined = append(refined, byRank[rank])
+		}
+		if len(unranked) > 0 {
+			refined = append(refined, unranked)
+		}
+	}
+
+	return refined
+}
+
+// allSingletons reports whether no group holds more than one candidate
+func allSingletons(groups [][]int) bool {
+	for _, group := range groups {
+		if len(group) > 1 {
+			return false
+		}
+	}
+	return true
+}
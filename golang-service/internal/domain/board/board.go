@@ -0,0 +1,226 @@
+package board
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+)
+
+// Domain separators of the hashes and signatures of the bulletin board
+const (
+	BallotHashContext = "saracen-voting/board-ballot/v1"
+	EntryHashContext  = "saracen-voting/board-entry/v1"
+	CheckpointContext = "saracen-voting/board-checkpoint/v1"
+)
+
+// Bounds on the number of entries returned at once
+const (
+	DefaultEntryLimit = 100
+	MaxEntryLimit     = 1000
+)
+
+// Entry is one accepted encrypted ballot on an election's bulletin board. Entries are numbered
+// from 1 and each entry's hash chains the previous entry's hash with the entry's ballot hash,
+// so removing or rewriting any entry changes the hash of every entry after it.
+type Entry struct {
+	ElectionID string    `json:"election_id" db:"election_id"`
+	Sequence   int64     `json:"sequence" db:"sequence"`
+	BallotID   string    `json:"ballot_id" db:"ballot_id"`
+	BallotHash string    `json:"ballot_hash" db:"ballot_hash"`
+	PrevHash   string    `json:"prev_hash" db:"prev_hash"`
+	Hash       string    `json:"hash" db:"hash"`
+	AppendedAt time.Time `json:"appended_at" db:"appended_at"`
+}
+
+// EntriesResponse represents the response for listing bulletin board entries
+type EntriesResponse struct {
+	ElectionID string   `json:"election_id"`
+	Entries    []*Entry `json:"entries"`
+}
+
+// Checkpoint is the board operator's signature over the size and head hash of an election's board.
+// Observers keep checkpoints and ask for consistency proofs between them.
+type Checkpoint struct {
+	ElectionID string    `json:"election_id" db:"election_id"`
+	Size       int64     `json:"size" db:"size"`
+	HeadHash   string    `json:"head_hash" db:"head_hash"`
+	PublicKey  string    `json:"public_key" db:"public_key"`
+	Signature  string    `json:"signature" db:"signature"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// CheckpointsResponse represents the response for listing checkpoints
+type CheckpointsResponse struct {
+	ElectionID  string        `json:"election_id"`
+	Checkpoints []*Checkpoint `json:"checkpoints"`
+}
+
+// ConsistencyProof shows that the board at ToSize extends the board at FromSize: chaining the ballot
+// hashes of entries FromSize+1 to ToSize onto FromHash gives ToHash
+type ConsistencyProof struct {
+	ElectionID   string   `json:"election_id"`
+	FromSize     int64    `json:"from_size"`
+	FromHash     string   `json:"from_hash"`
+	ToSize       int64    `json:"to_size"`
+	ToHash       string   `json:"to_hash"`
+	BallotHashes []string `json:"ballot_hashes"`
+}
+
+// BallotHash hashes every field of an encrypted ballot that the voter submitted
+func BallotHash(b *ballot.EncryptedBallot) []byte {
+	hash := sha256.Sum256(ballot.ProofContext(BallotHashContext,
+		[]byte(b.ElectionID), []byte(b.BallotID), []byte(b.Ciphertext), []byte(b.ZKProof),
+		[]byte(b.VoterPubkey), []byte(b.Nullifier), []byte(b.Signature)))
+	return hash[:]
+}
+
+// GenesisHash is the previous hash of an election's first entry
+func GenesisHash(electionID string) []byte {
+	hash := sha256.Sum256(ballot.ProofContext(EntryHashContext, []byte(electionID)))
+	return hash[:]
+}
+
+// ChainHash returns the hash of the entry with the given sequence number and ballot hash
+func ChainHash(electionID string, prevHash []byte, sequence int64, ballotHash []byte) []byte {
+	hash := sha256.Sum256(ballot.ProofContext(EntryHashContext,
+		[]byte(electionID), prevHash, []byte(strconv.FormatInt(sequence, 10)), ballotHash))
+	return hash[:]
+}
+
+// NewEntry returns the entry that appends a ballot after head, the board's last entry or nil if it is empty
+func NewEntry(head *Entry, b *ballot.EncryptedBallot, appendedAt time.Time) (*Entry, error) {
+	prevHash := GenesisHash(b.ElectionID)
+	sequence := int64(1)
+	if head != nil {
+		var err error
+		if prevHash, err = hex.DecodeString(head.Hash); err != nil {
+			return nil, fmt.Errorf("invalid board head hash: %v", err)
+		}
+		sequence = head.Sequence + 1
+	}
+
+	ballotHash := BallotHash(b)
+	return &Entry{
+		ElectionID: b.ElectionID,
+		Sequence:   sequence,
+		BallotID:   b.BallotID,
+		BallotHash: hex.EncodeToString(ballotHash),
+		PrevHash:   hex.EncodeToString(prevHash),
+		Hash:       hex.EncodeToString(ChainHash(b.ElectionID, prevHash, sequence, ballotHash)),
+		AppendedAt: appendedAt,
+	}, nil
+}
+
+// CheckpointMessage is the message a checkpoint signs:
+// "saracen-voting/board-checkpoint/v1" || len(election_id) || election_id || len(size) || size || len(head_hash) || head_hash,
+// with the size as a decimal string and the decoded head hash
+func CheckpointMessage(electionID string, size int64, headHash []byte) []byte {
+	return ballot.ProofContext(CheckpointContext, []byte(electionID), []byte(strconv.FormatInt(size, 10)), headHash)
+}
+
+// NewCheckpoint signs the board of an election at the given size and head hash
+func NewCheckpoint(electionID string, size int64, headHash string, key ed25519.PrivateKey, createdAt time.Time) (*Checkpoint, error) {
+	head, err := hex.DecodeString(headHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid board head hash: %v", err)
+	}
+
+	return &Checkpoint{
+		ElectionID: electionID,
+		Size:       size,
+		HeadHash:   headHash,
+		PublicKey:  hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Signature:  base64.StdEncoding.EncodeToString(ed25519.Sign(key, CheckpointMessage(electionID, size, head))),
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// Verify checks the checkpoint's signature against its public key
+func (c *Checkpoint) Verify() error {
+	head, err := hex.DecodeString(c.HeadHash)
+	if err != nil {
+		return fmt.Errorf("invalid head_hash: %v", err)
+	}
+	publicKey, err := hex.DecodeString(c.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid public_key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(c.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %v", err)
+	}
+
+	if !ed25519.Verify(publicKey, CheckpointMessage(c.ElectionID, c.Size, head), signature) {
+		return fmt.Errorf("checkpoint signature does not verify")
+	}
+	return nil
+}
+
+// NewConsistencyProof builds the proof that the board at to extends the board at from, given the
+// entries after from up to to, in order
+func NewConsistencyProof(electionID string, from, to int64, fromHash string, entries []*Entry) (*ConsistencyProof, error) {
+	if int64(len(entries)) != to-from {
+		return nil, fmt.Errorf("board of election %s is inconsistent: %d entries between sizes %d and %d, expected %d", electionID, len(entries), from, to, to-from)
+	}
+
+	proof := &ConsistencyProof{
+		ElectionID:   electionID,
+		FromSize:     from,
+		FromHash:     fromHash,
+		ToSize:       to,
+		ToHash:       fromHash,
+		BallotHashes: make([]string, len(entries)),
+	}
+	for i, entry := range entries {
+		proof.BallotHashes[i] = entry.BallotHash
+		proof.ToHash = entry.Hash
+	}
+	return proof, nil
+}
+
+// Verify recomputes the chain from FromHash over the ballot hashes and checks it ends at ToHash
+func (p *ConsistencyProof) Verify() error {
+	if int64(len(p.BallotHashes)) != p.ToSize-p.FromSize {
+		return fmt.Errorf("consistency proof must have %d ballot hashes, got %d", p.ToSize-p.FromSize, len(p.BallotHashes))
+	}
+
+	hash, err := hex.DecodeString(p.FromHash)
+	if err != nil {
+		return fmt.Errorf("invalid from_hash: %v", err)
+	}
+	for i, ballotHash := range p.BallotHashes {
+		decoded, err := hex.DecodeString(ballotHash)
+		if err != nil {
+			return fmt.Errorf("invalid ballot hash %d: %v", i, err)
+		}
+		hash = ChainHash(p.ElectionID, hash, p.FromSize+int64(i)+1, decoded)
+	}
+
+	toHash, err := hex.DecodeString(p.ToHash)
+	if err != nil {
+		return fmt.Errorf("invalid to_hash: %v", err)
+	}
+	if !bytes.Equal(hash, toHash) {
+		return fmt.Errorf("board at size %d does not extend the board at size %d", p.ToSize, p.FromSize)
+	}
+	return nil
+}
+
+// Repository defines the storage of bulletin board entries and checkpoints. Entries are
+// appended by the encrypted ballot repository in the transaction that stores the ballot.
+type Repository interface {
+	GetHead(electionID string) (*Entry, error)
+	GetEntries(electionID string, after int64, limit int) ([]*Entry, error)
+	GetEntry(electionID string, sequence int64) (*Entry, error)
+	CreateCheckpoint(checkpoint *Checkpoint) error
+	GetCheckpoints(electionID string) ([]*Checkpoint, error)
+	GetCheckpoint(electionID string, size int64) (*Checkpoint, error)
+	GetUncheckpointedElections() ([]string, error)
+}
@@ -0,0 +1,54 @@
+package candidate
+
+import (
+	"errors"
+)
+
+// Candidate represents the domain model for a candidate
+type Candidate struct {
+	CandidateID int    `json:"candidate_id"`
+	Name        string `json:"name"`
+	Party       string `json:"party"`
+	VotesCount  int    `json:"votes_count"`
+}
+
+// CandidateRequest represents the request payload for creating/updating a candidate
+type CandidateRequest struct {
+	Name  string `json:"name"`
+	Party string `json:"party"`
+}
+
+// CandidatesListResponse represents the response for listing candidates
+type CandidatesListResponse struct {
+	Candidates []*Candidate `json:"candidates"`
+}
+
+// Validate validates the candidate request
+func (req *CandidateRequest) Validate() error {
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	if req.Party == "" {
+		return errors.New("party is required")
+	}
+	return nil
+}
+
+// Repository defines the interface for candidate data operations
+type Repository interface {
+	Create(candidate *Candidate) error
+	GetByID(candidateID int) (*Candidate, error)
+	GetAll(party string) ([]*Candidate, error)
+	Update(candidate *Candidate) error
+	Delete(candidateID int) error
+	FindMissing(candidateIDs []int) ([]int, error)
+}
+
+// Service defines the interface for candidate business logic
+type Service interface {
+	CreateCandidate(req CandidateRequest) (*Candidate, error)
+	GetCandidate(candidateID int) (*Candidate, error)
+	GetAllCandidates(party string) (*CandidatesListResponse, error)
+	UpdateCandidate(candidateID int, req CandidateRequest) (*Candidate, error)
+	DeleteCandidate(candidateID int) error
+}
@@ -0,0 +1,267 @@
+package election
+
+import (
+	"fmt"
+	"time"
+)
+
+// Ballot types an election can accept
+const (
+	BallotTypeRanked    = "ranked"
+	BallotTypeEncrypted = "encrypted"
+	BallotTypeApproval  = "approval"
+	BallotTypeScore     = "score"
+)
+
+// Revoting policies: whether a voter's second ballot in an election is rejected, or replaces
+// the first so that only the voter's last ballot before polls close is counted
+const (
+	RevotePolicyReject     = "reject"
+	RevotePolicyLastBallot = "last_ballot"
+)
+
+// Bounds on the highest score a score ballot may give a candidate
+const (
+	DefaultMaxScore = 5
+	MaxMaxScore     = 100
+)
+
+// Election represents an election that ballots are cast in
+type Election struct {
+	ElectionID   string    `json:"election_id" db:"election_id"`
+	Title        string    `json:"title" db:"title"`
+	BallotType   string    `json:"ballot_type" db:"ballot_type"`
+	MaxScore     int       `json:"max_score,omitempty" db:"max_score"`
+	CandidateIDs []int     `json:"candidate_ids"`
+	OpensAt      time.Time `json:"opens_at" db:"opens_at"`
+	ClosesAt     time.Time `json:"closes_at" db:"closes_at"`
+	Phase        string    `json:"phase" db:"phase"`
+	CreatedAt    time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty" db:"updated_at"`
+
+	// Method is the official counting method; only its results with MethodOptions are official
+	Method        string            `json:"method,omitempty" db:"method"`
+	MethodOptions map[string]string `json:"method_options,omitempty" db:"method_options"`
+
+	// StrictSignatures takes encrypted ballot fields as sent instead of coercing them
+	StrictSignatures bool `json:"strict_signatures" db:"strict_signatures"`
+
+	// MaxSelections is the most candidates an encrypted ballot may select
+	MaxSelections int `json:"max_selections,omitempty" db:"max_selections"`
+
+	// SecretBallot stores ranked ballots without their voter or timestamp; only the voter's
+	// participation is recorded, apart from the ballot
+	SecretBallot bool `json:"secret_ballot" db:"secret_ballot"`
+
+	// RevotePolicy is what happens to a voter's second ballot
+	RevotePolicy string `json:"revote_policy" db:"revote_policy"`
+}
+
+// ElectionRequest represents the request payload for creating/updating an election
+type ElectionRequest struct {
+	ElectionID   string    `json:"election_id"`
+	Title        string    `json:"title"`
+	BallotType   string    `json:"ballot_type"`
+	MaxScore     int       `json:"max_score,omitempty"`
+	CandidateIDs []int     `json:"candidate_ids"`
+	OpensAt      time.Time `json:"opens_at"`
+	ClosesAt     time.Time `json:"closes_at"`
+
+	// Method defaults to the ballot type's default counting method
+	Method        string            `json:"method,omitempty"`
+	MethodOptions map[string]string `json:"method_options,omitempty"`
+
+	StrictSignatures bool `json:"strict_signatures,omitempty"`
+	MaxSelections    int  `json:"max_selections,omitempty"`
+	SecretBallot     bool `json:"secret_ballot,omitempty"`
+
+	// RevotePolicy defaults to rejecting a voter's second ballot
+	RevotePolicy string `json:"revote_policy,omitempty"`
+}
+
+// ElectionsListResponse represents the response for listing all elections
+type ElectionsListResponse struct {
+	Elections []*Election `json:"elections"`
+}
+
+// Validate validates the election request
+func (req *ElectionRequest) Validate() error {
+	if len(req.ElectionID) < 3 {
+		return fmt.Errorf("invalid election_id format: must be at least 3 characters")
+	}
+
+	if req.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+
+	if !IsValidBallotType(req.BallotType) {
+		return fmt.Errorf("invalid ballot_type: %s", req.BallotType)
+	}
+
+	// Score elections default to a 0-5 scale; other ballot types have no scores
+	if req.BallotType == BallotTypeScore {
+		if req.MaxScore == 0 {
+			req.MaxScore = DefaultMaxScore
+		}
+		if req.MaxScore < 1 || req.MaxScore > MaxMaxScore {
+			return fmt.Errorf("max_score must be between 1 and %d", MaxMaxScore)
+		}
+	} else if req.MaxScore != 0 {
+		return fmt.Errorf("max_score is only valid for %s elections", BallotTypeScore)
+	}
+
+	if req.StrictSignatures && req.BallotType != BallotTypeEncrypted {
+		return fmt.Errorf("strict_signatures is only valid for %s elections", BallotTypeEncrypted)
+	}
+
+	if req.SecretBallot && req.BallotType != BallotTypeRanked {
+		return fmt.Errorf("secret_ballot is only valid for %s elections", BallotTypeRanked)
+	}
+
+	// Replacing a ballot needs to know which stored ballot is the voter's, and encrypted ballots
+	// stay on the append-only bulletin board under their nullifier
+	switch req.RevotePolicy {
+	case "":
+		req.RevotePolicy = RevotePolicyReject
+	case RevotePolicyReject:
+	case RevotePolicyLastBallot:
+		if req.BallotType == BallotTypeEncrypted {
+			return fmt.Errorf("revote_policy %s is not valid for %s elections", RevotePolicyLastBallot, BallotTypeEncrypted)
+		}
+		if req.SecretBallot {
+			return fmt.Errorf("revote_policy %s cannot be combined with secret_ballot", RevotePolicyLastBallot)
+		}
+	default:
+		return fmt.Errorf("invalid revote_policy: %s", req.RevotePolicy)
+	}
+
+	if len(req.CandidateIDs) == 0 {
+		return fmt.Errorf("candidate_ids cannot be empty")
+	}
+
+	candidateSet := make(map[int]bool)
+	for i, candidateID := range req.CandidateIDs {
+		if candidateID <= 0 {
+			return fmt.Errorf("candidate_id at position %d must be positive", i)
+		}
+		if candidateSet[candidateID] {
+			return fmt.Errorf("candidate_id %d appears multiple times in candidate_ids", candidateID)
+		}
+		candidateSet[candidateID] = true
+	}
+
+	// Encrypted elections default to selecting a single candidate
+	if req.BallotType == BallotTypeEncrypted {
+		if req.MaxSelections == 0 {
+			req.MaxSelections = 1
+		}
+		if req.MaxSelections < 1 || req.MaxSelections > len(req.CandidateIDs) {
+			return fmt.Errorf("max_selections must be between 1 and the number of candidates")
+		}
+	} else if req.MaxSelections != 0 {
+		return fmt.Errorf("max_selections is only valid for %s elections", BallotTypeEncrypted)
+	}
+
+	if req.OpensAt.IsZero() || req.ClosesAt.IsZero() {
+		return fmt.Errorf("opens_at and closes_at are required")
+	}
+
+	if !req.ClosesAt.After(req.OpensAt) {
+		return fmt.Errorf("invalid schedule: closes_at must be after opens_at")
+	}
+
+	return nil
+}
+
+// ToElection converts request to domain model
+func (req *ElectionRequest) ToElection() (*Election, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Election{
+		ElectionID:       req.ElectionID,
+		Title:            req.Title,
+		BallotType:       req.BallotType,
+		MaxScore:         req.MaxScore,
+		CandidateIDs:     req.CandidateIDs,
+		OpensAt:          req.OpensAt,
+		ClosesAt:         req.ClosesAt,
+		Phase:            PhaseDraft,
+		Method:           req.Method,
+		MethodOptions:    req.MethodOptions,
+		StrictSignatures: req.StrictSignatures,
+		MaxSelections:    req.MaxSelections,
+		SecretBallot:     req.SecretBallot,
+		RevotePolicy:     req.RevotePolicy,
+	}, nil
+}
+
+// AllowsRevoting reports whether a voter's new ballot replaces their earlier one
+func (e *Election) AllowsRevoting() bool {
+	return e.RevotePolicy == RevotePolicyLastBallot
+}
+
+// HasCandidate reports whether the candidate is on the election's slate
+func (e *Election) HasCandidate(candidateID int) bool {
+	for _, id := range e.CandidateIDs {
+		if id == candidateID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOfficialMethod reports whether counting with the method and options gives the official results.
+// Options left unset are compared as empty.
+func (e *Election) IsOfficialMethod(method string, options map[string]string) bool {
+	return method == e.Method && sameOptions(options, e.MethodOptions)
+}
+
+// sameOptions reports whether two option sets hold the same non-empty values
+func sameOptions(a, b map[string]string) bool {
+	for name, value := range a {
+		if value != b[name] {
+			return false
+		}
+	}
+	for name, value := range b {
+		if value != a[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidBallotType reports whether the ballot type is supported
+func IsValidBallotType(ballotType string) bool {
+	switch ballotType {
+	case BallotTypeRanked, BallotTypeEncrypted, BallotTypeApproval, BallotTypeScore:
+		return true
+	}
+	return false
+}
+
+// Repository defines the interface for election data operations
+type Repository interface {
+	Create(election *Election) error
+	GetByID(electionID string) (*Election, error)
+	GetAll() ([]*Election, error)
+	Update(election *Election) error
+	Delete(electionID string) error
+	ExistsByID(electionID string) (bool, error)
+	GetByPhase(phases ...string) ([]*Election, error)
+	SaveTransition(election *Election, transition *Transition) error
+	GetTransitions(electionID string) ([]*Transition, error)
+}
+
+// Service defines the interface for election business logic
+type Service interface {
+	CreateElection(req ElectionRequest) (*Election, error)
+	GetElection(electionID string) (*Election, error)
+	GetAllElections() (*ElectionsListResponse, error)
+	UpdateElection(electionID string, req ElectionRequest) (*Election, error)
+	DeleteElection(electionID string) error
+	TransitionElection(electionID string, req TransitionRequest) (*Election, error)
+	GetElectionTransitions(electionID string) ([]*Transition, error)
+}
@@ -0,0 +1,138 @@
+package election
+
+import (
+	"fmt"
+	"time"
+)
+
+// Election phases, in lifecycle order
+const (
+	PhaseDraft        = "draft"
+	PhaseRegistration = "registration"
+	PhaseVoting       = "voting"
+	PhaseClosed       = "closed"
+	PhaseTallied      = "tallied"
+	PhaseCertified    = "certified"
+)
+
+// ActorScheduler is recorded as the actor of transitions made by the scheduler
+const ActorScheduler = "scheduler"
+
+// phaseTransitions lists the phase each phase may advance to
+var phaseTransitions = map[string]string{
+	PhaseDraft:        PhaseRegistration,
+	PhaseRegistration: PhaseVoting,
+	PhaseVoting:       PhaseClosed,
+	PhaseClosed:       PhaseTallied,
+	PhaseTallied:      PhaseCertified,
+}
+
+// Transition records a single phase change of an election
+type Transition struct {
+	ID             int       `json:"id" db:"id"`
+	ElectionID     string    `json:"election_id" db:"election_id"`
+	FromPhase      string    `json:"from_phase" db:"from_phase"`
+	ToPhase        string    `json:"to_phase" db:"to_phase"`
+	Actor          string    `json:"actor" db:"actor"`
+	TransitionedAt time.Time `json:"transitioned_at" db:"transitioned_at"`
+}
+
+// TransitionRequest represents the request payload for moving an election to a new phase
+type TransitionRequest struct {
+	Phase string `json:"phase"`
+	Actor string `json:"actor"`
+}
+
+// TransitionsResponse represents the phase history of an election
+type TransitionsResponse struct {
+	ElectionID  string        `json:"election_id"`
+	Transitions []*Transition `json:"transitions"`
+}
+
+// Validate validates the transition request
+func (req *TransitionRequest) Validate() error {
+	if !IsValidPhase(req.Phase) {
+		return fmt.Errorf("invalid phase: %s", req.Phase)
+	}
+
+	if req.Actor == "" {
+		return fmt.Errorf("actor is required")
+	}
+
+	return nil
+}
+
+// IsValidPhase reports whether the phase is a known election phase
+func IsValidPhase(phase string) bool {
+	_, ok := phaseTransitions[phase]
+	return ok || phase == PhaseCertified
+}
+
+// CanTransitionTo reports whether the election may move directly to the given phase
+func (e *Election) CanTransitionTo(phase string) bool {
+	return phaseTransitions[e.Phase] == phase
+}
+
+// TransitionTo moves the election to the given phase and returns the transition record
+func (e *Election) TransitionTo(phase, actor string, at time.Time) (*Transition, error) {
+	if !e.CanTransitionTo(phase) {
+		return nil, fmt.Errorf("invalid phase transition for election %s: %s -> %s", e.ElectionID, e.Phase, phase)
+	}
+
+	transition := &Transition{
+		ElectionID:     e.ElectionID,
+		FromPhase:      e.Phase,
+		ToPhase:        phase,
+		Actor:          actor,
+		TransitionedAt: at,
+	}
+	e.Phase = phase
+
+	return transition, nil
+}
+
+// AcceptsBallot returns an error if a ballot of the given type cannot be cast in the election at the given time
+func (e *Election) AcceptsBallot(ballotType string, at time.Time) error {
+	if e.Phase != PhaseVoting {
+		return fmt.Errorf("election %s is not accepting ballots in phase %s", e.ElectionID, e.Phase)
+	}
+
+	// The scheduler may lag behind the configured closing time
+	if !at.Before(e.ClosesAt) {
+		return fmt.Errorf("election %s is not accepting ballots in phase %s: polls closed at %s",
+			e.ElectionID, e.Phase, e.ClosesAt.Format(time.RFC3339))
+	}
+
+	if e.BallotType != ballotType {
+		return fmt.Errorf("election %s does not accept %s ballots", e.ElectionID, ballotType)
+	}
+
+	return nil
+}
+
+// AllowsResults returns an error if results of the election cannot be published in its current phase
+func (e *Election) AllowsResults() error {
+	switch e.Phase {
+	case PhaseClosed, PhaseTallied, PhaseCertified:
+		return nil
+	}
+	return fmt.Errorf("results for election %s are not available in phase %s", e.ElectionID, e.Phase)
+}
+
+// FreezesVoterRoll reports whether voter records must not change while the election is in its current phase.
+// The roll is frozen from the start of voting until the results are certified.
+func (e *Election) FreezesVoterRoll() bool {
+	return e.Phase == PhaseVoting || e.Phase == PhaseClosed || e.Phase == PhaseTallied
+}
+
+// IsDue reports whether the scheduler should advance the election at the given time,
+// returning the phase it should move to
+func (e *Election) IsDue(at time.Time) (string, bool) {
+	switch {
+	case e.Phase == PhaseRegistration && !at.Before(e.OpensAt):
+		return PhaseVoting, true
+	case e.Phase == PhaseVoting && !at.Before(e.ClosesAt):
+		return PhaseClosed, true
+	}
+	return "", false
+}
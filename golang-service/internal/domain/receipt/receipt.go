@@ -0,0 +1,111 @@
+package receipt
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/board"
+)
+
+// RankedBallotHashContext is the domain separator of the hash of a ranked ballot
+const RankedBallotHashContext = "saracen-voting/ranked-ballot/v1"
+
+// Ballot types a receipt can be issued for
+const (
+	BallotTypeEncrypted = "encrypted"
+	BallotTypeRanked    = "ranked"
+)
+
+// Tracking codes are the first 80 bits of a ballot hash: 16 base32 characters written in groups of 4
+const (
+	codeBytes     = 10
+	codeLength    = 16
+	codeGroupSize = 4
+)
+
+// Receipt confirms that a ballot is part of its election's published record without revealing its choices.
+// Encrypted ballots are located on the election's bulletin board; CheckpointSize is the smallest signed
+// checkpoint that covers the ballot's entry, if one has been signed yet.
+type Receipt struct {
+	TrackingCode   string `json:"tracking_code"`
+	ElectionID     string `json:"election_id"`
+	BallotType     string `json:"ballot_type"`
+	Status         string `json:"status"`
+	Included       bool   `json:"included"`
+	BoardSequence  *int64 `json:"board_sequence,omitempty"`
+	BallotHash     string `json:"ballot_hash,omitempty"`
+	EntryHash      string `json:"entry_hash,omitempty"`
+	CheckpointSize *int64 `json:"checkpoint_size,omitempty"`
+}
+
+// codeEncoding is unpadded uppercase base32
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TrackingCode returns the tracking code of a ballot hash, such as "MFRG-GZDF-MZTW-Q2LK"
+func TrackingCode(hash []byte) string {
+	code := codeEncoding.EncodeToString(hash[:codeBytes])
+
+	groups := make([]string, 0, codeLength/codeGroupSize)
+	for i := 0; i < len(code); i += codeGroupSize {
+		groups = append(groups, code[i:i+codeGroupSize])
+	}
+	return strings.Join(groups, "-")
+}
+
+// NormalizeCode returns a tracking code as TrackingCode writes it. Voters may type it
+// in lowercase and with or without the dashes and spaces between groups.
+func NormalizeCode(code string) (string, error) {
+	compact := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(compact) != codeLength {
+		return "", fmt.Errorf("invalid tracking code: must be %d characters", codeLength)
+	}
+	decoded, err := codeEncoding.DecodeString(compact)
+	if err != nil || len(decoded) != codeBytes {
+		return "", fmt.Errorf("invalid tracking code format")
+	}
+	return TrackingCode(decoded), nil
+}
+
+// EncryptedBallotCode returns the tracking code of an encrypted ballot, derived from the
+// ballot hash of its bulletin board entry
+func EncryptedBallotCode(b *ballot.EncryptedBallot) string {
+	return TrackingCode(board.BallotHash(b))
+}
+
+// RankedBallotHash hashes a stored ranked ballot:
+// "saracen-voting/ranked-ballot/v1" || len(election_id) || election_id || len(ballot_id) || ballot_id || rankings...,
+// with each ranking written as "rank_position:candidate_id", ordered by rank position then candidate.
+// The random ballot ID keeps the choices from being recovered by hashing every possible ranking.
+func RankedBallotHash(b *ballot.RankedBallot, rankings []ballot.BallotRanking) []byte {
+	sorted := make([]ballot.BallotRanking, len(rankings))
+	copy(sorted, rankings)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].RankPosition != sorted[j].RankPosition {
+			return sorted[i].RankPosition < sorted[j].RankPosition
+		}
+		return sorted[i].CandidateID < sorted[j].CandidateID
+	})
+
+	fields := [][]byte{[]byte(b.ElectionID), []byte(b.BallotID)}
+	for _, ranking := range sorted {
+		fields = append(fields, []byte(strconv.Itoa(ranking.RankPosition)+":"+strconv.Itoa(ranking.CandidateID)))
+	}
+
+	hash := sha256.Sum256(ballot.ProofContext(RankedBallotHashContext, fields...))
+	return hash[:]
+}
+
+// RankedBallotCode returns the tracking code of a ranked ballot
+func RankedBallotCode(b *ballot.RankedBallot, rankings []ballot.BallotRanking) string {
+	return TrackingCode(RankedBallotHash(b, rankings))
+}
+
+// Repository defines the lookup of receipts by tracking code across the ballot tables
+type Repository interface {
+	GetByTrackingCode(code string) (*Receipt, error)
+}
@@ -0,0 +1,258 @@
+package trustee
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+)
+
+// DecryptionShareContext prefixes the context of every decryption share proof
+const DecryptionShareContext = "saracen-voting/decryption-share/v1"
+
+// CommitmentProofContext prefixes the context of every proof of knowledge of a dealer's constant term
+const CommitmentProofContext = "saracen-voting/dealer-commitment/v1"
+
+// Polynomial is a trustee's secret polynomial of degree threshold-1 over the exponents.
+// Its constant term is the trustee's contribution to the election secret key.
+type Polynomial struct {
+	coefficients []*big.Int
+}
+
+// NewPolynomial generates a random polynomial for a ceremony with the given threshold
+func NewPolynomial(threshold int, random io.Reader) (*Polynomial, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("threshold must be at least 1")
+	}
+
+	coefficients := make([]*big.Int, threshold)
+	for k := range coefficients {
+		coefficient, err := ballot.ElGamalGroup.RandomExponent(random)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[k] = coefficient
+	}
+	return &Polynomial{coefficients: coefficients}, nil
+}
+
+// Share evaluates the polynomial at a trustee's index, giving the share the dealer sends that trustee
+func (f *Polynomial) Share(index int) *big.Int {
+	q := ballot.ElGamalGroup.Q
+	x := big.NewInt(int64(index))
+
+	// Horner's rule
+	share := new(big.Int)
+	for k := len(f.coefficients) - 1; k >= 0; k-- {
+		share.Mul(share, x)
+		share.Add(share, f.coefficients[k])
+		share.Mod(share, q)
+	}
+	return share
+}
+
+// Commitments returns the Feldman commitments G^a_k to the polynomial's coefficients
+func (f *Polynomial) Commitments() []*big.Int {
+	commitments := make([]*big.Int, len(f.coefficients))
+	for k, coefficient := range f.coefficients {
+		commitments[k] = ballot.ElGamalGroup.Exp(coefficient)
+	}
+	return commitments
+}
+
+// ProveConstantTerm proves that the dealer at index knows the constant term behind its commitment C_0
+func (f *Polynomial) ProveConstantTerm(electionID string, index int, random io.Reader) (ballot.ChaumPedersenProof, error) {
+	g := ballot.ElGamalGroup
+	return g.ProveKnowledge(commitmentContext(electionID, index), g.Exp(f.coefficients[0]), f.coefficients[0], random)
+}
+
+// VerifyConstantTerm reports whether a proof shows that the dealer at index knows log_G(C_0) of its
+// commitments. Without it, a dealer committing after the others could publish C_0 = G^s / Π others' C_0
+// and make G^s, whose secret only it knows, the election key.
+func VerifyConstantTerm(electionID string, index int, commitments []*big.Int, proof ballot.ChaumPedersenProof) bool {
+	if len(commitments) == 0 {
+		return false
+	}
+	return ballot.ElGamalGroup.VerifyKnowledge(commitmentContext(electionID, index), commitments[0], proof)
+}
+
+// ShareCommitment returns G^f(index) computed from a dealer's commitments alone,
+// as the product of C_k^(index^k)
+func ShareCommitment(commitments []*big.Int, index int) *big.Int {
+	g := ballot.ElGamalGroup
+	x := big.NewInt(int64(index))
+
+	result := big.NewInt(1)
+	power := big.NewInt(1)
+	for _, commitment := range commitments {
+		result.Mul(result, new(big.Int).Exp(commitment, power, g.P))
+		result.Mod(result, g.P)
+		power.Mul(power, x)
+	}
+	return result
+}
+
+// VerifyShare reports whether a share a dealer sent the trustee at index matches the dealer's commitments
+func VerifyShare(commitments []*big.Int, index int, share *big.Int) bool {
+	g := ballot.ElGamalGroup
+	if share.Sign() < 0 || share.Cmp(g.Q) >= 0 {
+		return false
+	}
+	return g.Exp(share).Cmp(ShareCommitment(commitments, index)) == 0
+}
+
+// CombineShares returns a trustee's secret key share: the sum of the shares the qualified dealers sent it
+func CombineShares(shares []*big.Int) *big.Int {
+	secret := new(big.Int)
+	for _, share := range shares {
+		secret.Add(secret, share)
+	}
+	return secret.Mod(secret, ballot.ElGamalGroup.Q)
+}
+
+// JointPublicKey returns the election public key, the product of every qualified dealer's constant commitment
+func JointPublicKey(dealerCommitments [][]*big.Int) *big.Int {
+	g := ballot.ElGamalGroup
+	publicKey := big.NewInt(1)
+	for _, commitments := range dealerCommitments {
+		publicKey.Mul(publicKey, commitments[0])
+		publicKey.Mod(publicKey, g.P)
+	}
+	return publicKey
+}
+
+// VerificationKey returns G^x for the secret key share x of the trustee at index,
+// computed from the qualified dealers' commitments
+func VerificationKey(dealerCommitments [][]*big.Int, index int) *big.Int {
+	g := ballot.ElGamalGroup
+	key := big.NewInt(1)
+	for _, commitments := range dealerCommitments {
+		key.Mul(key, ShareCommitment(commitments, index))
+		key.Mod(key, g.P)
+	}
+	return key
+}
+
+// PartialDecryption is one trustee's decryption factor Alpha^x for each candidate's aggregate
+// ciphertext, with proofs that each uses the secret behind the trustee's verification key
+type PartialDecryption struct {
+	TrusteeIndex int
+	Factors      []*big.Int
+	Proofs       []ballot.ChaumPedersenProof
+}
+
+// PartiallyDecrypt computes a trustee's decryption factors for an election's aggregate ciphertext
+func PartiallyDecrypt(electionID string, index int, secret *big.Int, aggregate ballot.BallotCiphertext, random io.Reader) (*PartialDecryption, error) {
+	g := ballot.ElGamalGroup
+	verificationKey := g.Exp(secret)
+
+	decryption := &PartialDecryption{
+		TrusteeIndex: index,
+		Factors:      make([]*big.Int, len(aggregate)),
+		Proofs:       make([]ballot.ChaumPedersenProof, len(aggregate)),
+	}
+	for i, ciphertext := range aggregate {
+		factor := g.DecryptionFactor(ciphertext, secret)
+		proof, err := g.ProveEqualLogs(decryptionContext(electionID, index, i), g.G, verificationKey, ciphertext.Alpha, factor, secret, random)
+		if err != nil {
+			return nil, err
+		}
+		decryption.Factors[i] = factor
+		decryption.Proofs[i] = proof
+	}
+	return decryption, nil
+}
+
+// Verify checks every factor's proof against the trustee's verification key and the aggregate ciphertext
+func (d *PartialDecryption) Verify(electionID string, verificationKey *big.Int, aggregate ballot.BallotCiphertext) error {
+	g := ballot.ElGamalGroup
+	if len(d.Factors) != len(aggregate) || len(d.Proofs) != len(aggregate) {
+		return fmt.Errorf("invalid decryption share: must have one factor and proof for each of %d candidates", len(aggregate))
+	}
+
+	for i, ciphertext := range aggregate {
+		if !g.IsElement(d.Factors[i]) {
+			return fmt.Errorf("invalid decryption share: factor %d is not an element of the group", i+1)
+		}
+		if !g.VerifyEqualLogs(decryptionContext(electionID, d.TrusteeIndex, i), g.G, verificationKey, ciphertext.Alpha, d.Factors[i], d.Proofs[i]) {
+			return fmt.Errorf("invalid decryption share: proof %d does not verify", i+1)
+		}
+	}
+	return nil
+}
+
+// CombineDecryptions interpolates the partial decryptions of at least threshold trustees
+// into the full decryption factor of each candidate's aggregate ciphertext
+func CombineDecryptions(decryptions []*PartialDecryption, candidates int) ([]*big.Int, error) {
+	g := ballot.ElGamalGroup
+
+	indices := make([]int, len(decryptions))
+	for i, decryption := range decryptions {
+		indices[i] = decryption.TrusteeIndex
+	}
+	coefficients, err := LagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := make([]*big.Int, candidates)
+	for i := range factors {
+		factor := big.NewInt(1)
+		for j, decryption := range decryptions {
+			factor.Mul(factor, new(big.Int).Exp(decryption.Factors[i], coefficients[j], g.P))
+			factor.Mod(factor, g.P)
+		}
+		factors[i] = factor
+	}
+	return factors, nil
+}
+
+// LagrangeCoefficients returns the coefficient of each trustee index for interpolating
+// the shared polynomial at zero from the shares of exactly those trustees
+func LagrangeCoefficients(indices []int) ([]*big.Int, error) {
+	q := ballot.ElGamalGroup.Q
+
+	sorted := append([]int(nil), indices...)
+	sort.Ints(sorted)
+	for i, index := range sorted {
+		if index < 1 {
+			return nil, fmt.Errorf("invalid trustee index: %d", index)
+		}
+		if i > 0 && sorted[i-1] == index {
+			return nil, fmt.Errorf("duplicate trustee index: %d", index)
+		}
+	}
+
+	coefficients := make([]*big.Int, len(indices))
+	for i, index := range indices {
+		numerator := big.NewInt(1)
+		denominator := big.NewInt(1)
+		for _, other := range indices {
+			if other == index {
+				continue
+			}
+			// lambda_i = product of j / (j - i) over the other indices j
+			numerator.Mul(numerator, big.NewInt(int64(other)))
+			denominator.Mul(denominator, big.NewInt(int64(other-index)))
+		}
+		denominator.Mod(denominator, q)
+		coefficient := numerator.Mul(numerator, denominator.ModInverse(denominator, q))
+		coefficients[i] = coefficient.Mod(coefficient, q)
+	}
+	return coefficients, nil
+}
+
+// commitmentContext binds a dealer's proof of knowledge to the election and the dealer, so it cannot be
+// replayed by another dealer or in another ceremony
+func commitmentContext(electionID string, index int) []byte {
+	return ballot.ProofContext(CommitmentProofContext, []byte(electionID), []byte(strconv.Itoa(index)))
+}
+
+// decryptionContext binds a decryption proof to the election, the trustee and the candidate position
+func decryptionContext(electionID string, index, candidate int) []byte {
+	return ballot.ProofContext(DecryptionShareContext,
+		[]byte(electionID), []byte(strconv.Itoa(index)), []byte(strconv.Itoa(candidate)))
+}
@@ -0,0 +1,238 @@
+package trustee
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+)
+
+// simulatedTrustee is a trustee run in process: its polynomial and the shares dealt to it
+type simulatedTrustee struct {
+	index      int
+	polynomial *Polynomial
+	received   map[int]*big.Int
+}
+
+// runCeremony runs a key generation among n trustees with the given threshold, every trustee
+// checking each share it receives against the dealer's commitments
+func runCeremony(t *testing.T, n, threshold int) ([]*simulatedTrustee, [][]*big.Int) {
+	t.Helper()
+
+	trustees := make([]*simulatedTrustee, n)
+	commitments := make([][]*big.Int, n)
+	for i := range trustees {
+		polynomial, err := NewPolynomial(threshold, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trustees[i] = &simulatedTrustee{index: i + 1, polynomial: polynomial, received: make(map[int]*big.Int)}
+		commitments[i] = polynomial.Commitments()
+	}
+
+	for _, dealer := range trustees {
+		for _, recipient := range trustees {
+			share := dealer.polynomial.Share(recipient.index)
+			if !VerifyShare(commitments[dealer.index-1], recipient.index, share) {
+				t.Fatalf("share from trustee %d to trustee %d does not match the commitments", dealer.index, recipient.index)
+			}
+			recipient.received[dealer.index] = share
+		}
+	}
+
+	return trustees, commitments
+}
+
+// secret returns the trustee's secret key share
+func (s *simulatedTrustee) secret() *big.Int {
+	shares := make([]*big.Int, 0, len(s.received))
+	for _, share := range s.received {
+		shares = append(shares, share)
+	}
+	return CombineShares(shares)
+}
+
+// encryptTally encrypts ballots under the public key and returns their aggregate
+func encryptTally(t *testing.T, publicKey *big.Int, ballots [][]int) ballot.BallotCiphertext {
+	t.Helper()
+
+	aggregate := ballot.NewBallotAggregate(len(ballots[0]))
+	for _, votes := range ballots {
+		ciphertext, _, err := ballot.EncryptBallot(publicKey, votes, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aggregate.Add(ciphertext)
+	}
+	return aggregate
+}
+
+func TestThresholdDecryptionWithAnyThresholdTrustees(t *testing.T) {
+	const electionID = "trustee-test"
+	trustees, commitments := runCeremony(t, 5, 3)
+	publicKey := JointPublicKey(commitments)
+
+	ballots := [][]int{{1, 0, 0}, {0, 1, 0}, {1, 0, 0}, {0, 0, 1}, {1, 0, 0}}
+	want := []int{3, 1, 1}
+	aggregate := encryptTally(t, publicKey, ballots)
+
+	decryptions := make([]*PartialDecryption, len(trustees))
+	for i, trustee := range trustees {
+		decryption, err := PartiallyDecrypt(electionID, trustee.index, trustee.secret(), aggregate, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := decryption.Verify(electionID, VerificationKey(commitments, trustee.index), aggregate); err != nil {
+			t.Fatalf("trustee %d: %v", trustee.index, err)
+		}
+		decryptions[i] = decryption
+	}
+
+	for _, subset := range [][]int{{1, 2, 3}, {2, 4, 5}, {5, 1, 3}, {1, 2, 3, 4, 5}} {
+		chosen := make([]*PartialDecryption, len(subset))
+		for i, index := range subset {
+			chosen[i] = decryptions[index-1]
+		}
+
+		factors, err := CombineDecryptions(chosen, len(aggregate))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, ciphertext := range aggregate {
+			votes, err := ballot.ElGamalGroup.DecryptWithFactor(ciphertext, factors[i], len(ballots))
+			if err != nil {
+				t.Fatalf("trustees %v, candidate %d: %v", subset, i+1, err)
+			}
+			if votes != want[i] {
+				t.Errorf("trustees %v, candidate %d = %d votes, want %d", subset, i+1, votes, want[i])
+			}
+		}
+	}
+
+	// Fewer than threshold trustees interpolate the wrong polynomial
+	factors, err := CombineDecryptions(decryptions[:2], len(aggregate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if votes, err := ballot.ElGamalGroup.DecryptWithFactor(aggregate[0], factors[0], len(ballots)); err == nil && votes == want[0] {
+		t.Error("two of three trustees decrypted the tally")
+	}
+}
+
+func TestVerifyShareRejectsTamperedShare(t *testing.T) {
+	polynomial, err := NewPolynomial(3, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitments := polynomial.Commitments()
+
+	share := polynomial.Share(2)
+	if !VerifyShare(commitments, 2, share) {
+		t.Fatal("valid share rejected")
+	}
+	if VerifyShare(commitments, 3, share) {
+		t.Error("share accepted for the wrong trustee")
+	}
+	if VerifyShare(commitments, 2, new(big.Int).Add(share, big.NewInt(1))) {
+		t.Error("tampered share accepted")
+	}
+}
+
+func TestVerifyConstantTermRejectsRogueDealer(t *testing.T) {
+	const electionID = "trustee-test"
+	g := ballot.ElGamalGroup
+	trustees, commitments := runCeremony(t, 3, 2)
+
+	for _, honest := range trustees {
+		proof, err := honest.polynomial.ProveConstantTerm(electionID, honest.index, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyConstantTerm(electionID, honest.index, commitments[honest.index-1], proof) {
+			t.Fatalf("valid proof of trustee %d rejected", honest.index)
+		}
+	}
+
+	// The last dealer commits to C_0 = G^s / (C_0 of the others), which makes G^s the election key
+	s, err := g.RandomExponent(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rogue := []*big.Int{g.Exp(s), commitments[2][1]}
+	for _, other := range commitments[:2] {
+		rogue[0].Mul(rogue[0], new(big.Int).ModInverse(other[0], g.P))
+		rogue[0].Mod(rogue[0], g.P)
+	}
+	if JointPublicKey([][]*big.Int{commitments[0], commitments[1], rogue}).Cmp(g.Exp(s)) != 0 {
+		t.Fatal("rogue commitments do not set the election key")
+	}
+
+	// The dealer knows s but not log_G(C_0), so it has no proof that verifies
+	forged, err := g.ProveKnowledge(commitmentContext(electionID, 3), rogue[0], s, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyConstantTerm(electionID, 3, rogue, forged) {
+		t.Error("rogue commitments accepted with a proof of s")
+	}
+	honest, err := trustees[2].polynomial.ProveConstantTerm(electionID, 3, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyConstantTerm(electionID, 3, rogue, honest) {
+		t.Error("rogue commitments accepted with the dealer's honest proof")
+	}
+
+	// Nor can it copy another dealer's commitments and proof, which are bound to their election and dealer
+	copied, err := trustees[0].polynomial.ProveConstantTerm(electionID, 1, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyConstantTerm(electionID, 3, commitments[0], copied) {
+		t.Error("proof accepted for another dealer")
+	}
+	if VerifyConstantTerm("other-election", 1, commitments[0], copied) {
+		t.Error("proof accepted for another election")
+	}
+}
+
+func TestPartialDecryptionRejectsInvalidProof(t *testing.T) {
+	const electionID = "trustee-test"
+	trustees, commitments := runCeremony(t, 3, 2)
+	aggregate := encryptTally(t, JointPublicKey(commitments), [][]int{{1, 0}, {0, 1}})
+
+	honest := trustees[0]
+	decryption, err := PartiallyDecrypt(electionID, honest.index, honest.secret(), aggregate, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verificationKey := VerificationKey(commitments, honest.index)
+
+	// A factor computed with another secret does not match the proof
+	forged := *decryption
+	forged.Factors = append([]*big.Int(nil), decryption.Factors...)
+	forged.Factors[0] = ballot.ElGamalGroup.DecryptionFactor(aggregate[0], trustees[1].secret())
+	if err := forged.Verify(electionID, verificationKey, aggregate); err == nil {
+		t.Error("forged factor accepted")
+	}
+
+	// A proof is bound to its election and trustee
+	if err := decryption.Verify("other-election", verificationKey, aggregate); err == nil {
+		t.Error("proof accepted for another election")
+	}
+	replayed := *decryption
+	replayed.TrusteeIndex = 2
+	if err := replayed.Verify(electionID, VerificationKey(commitments, 2), aggregate); err == nil {
+		t.Error("proof accepted for another trustee")
+	}
+}
+
+func TestLagrangeCoefficientsRejectDuplicateIndices(t *testing.T) {
+	if _, err := LagrangeCoefficients([]int{1, 2, 2}); err == nil {
+		t.Error("duplicate indices accepted")
+	}
+	if _, err := LagrangeCoefficients([]int{0, 1}); err == nil {
+		t.Error("index 0 accepted")
+	}
+}
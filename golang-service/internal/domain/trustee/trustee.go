@@ -0,0 +1,224 @@
+package trustee
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+)
+
+// MaxTrustees bounds the number of trustees of a ceremony
+const MaxTrustees = 50
+
+// Ceremony is the distributed key generation of an election's trustees. Each trustee deals
+// shares of a random polynomial to the others and publishes Feldman commitments to it; the
+// election public key is the product of the qualified dealers' constant commitments, and no
+// party ever holds the election secret key.
+type Ceremony struct {
+	ElectionID  string       `json:"election_id" db:"election_id"`
+	Threshold   int          `json:"threshold" db:"threshold"`
+	Trustees    []*Trustee   `json:"trustees"`
+	Complaints  []*Complaint `json:"complaints"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	FinalizedAt *time.Time   `json:"finalized_at,omitempty" db:"finalized_at"`
+}
+
+// Trustee is one member of an election's key generation ceremony, numbered from 1
+type Trustee struct {
+	ElectionID      string                     `json:"-" db:"election_id"`
+	Index           int                        `json:"index" db:"trustee_index"`
+	Name            string                     `json:"name" db:"name"`
+	Commitments     []string                   `json:"commitments" db:"commitments"`
+	CommitmentProof *ballot.ChaumPedersenProof `json:"commitment_proof,omitempty" db:"commitment_proof"`
+	CommittedAt     *time.Time                 `json:"committed_at,omitempty" db:"committed_at"`
+	Disqualified    bool                       `json:"disqualified" db:"disqualified"`
+	VerificationKey string                     `json:"verification_key,omitempty" db:"verification_key"`
+}
+
+// Complaint is a trustee's claim that the share a dealer sent it does not match the dealer's
+// commitments. The dealer answers by revealing the share; a dealer that does not, or reveals
+// an invalid share, is disqualified.
+type Complaint struct {
+	ElectionID    string     `json:"-" db:"election_id"`
+	Dealer        int        `json:"dealer" db:"dealer_index"`
+	Complainer    int        `json:"complainer" db:"complainer_index"`
+	RevealedShare string     `json:"revealed_share,omitempty" db:"revealed_share"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// DecryptionShare is a trustee's published partial decryption of an election's aggregate ciphertext
+type DecryptionShare struct {
+	ElectionID   string                      `json:"election_id" db:"election_id"`
+	TrusteeIndex int                         `json:"trustee_index" db:"trustee_index"`
+	Factors      []string                    `json:"factors" db:"factors"`
+	Proofs       []ballot.ChaumPedersenProof `json:"proofs" db:"proofs"`
+	SubmittedAt  time.Time                   `json:"submitted_at" db:"submitted_at"`
+}
+
+// CeremonyRequest represents the request payload for starting a key generation ceremony
+type CeremonyRequest struct {
+	Threshold int              `json:"threshold"`
+	Trustees  []TrusteeRequest `json:"trustees"`
+}
+
+// TrusteeRequest names one trustee of a ceremony
+type TrusteeRequest struct {
+	Name string `json:"name"`
+}
+
+// CommitmentsRequest represents a dealer's hex Feldman commitments, one per polynomial coefficient,
+// and its Schnorr proof of knowledge of the constant term
+type CommitmentsRequest struct {
+	Commitments     []string                   `json:"commitments"`
+	CommitmentProof *ballot.ChaumPedersenProof `json:"commitment_proof"`
+}
+
+// ComplaintRequest represents a trustee's complaint against a dealer
+type ComplaintRequest struct {
+	Dealer int `json:"dealer"`
+}
+
+// RevealRequest represents a dealer's public answer to a complaint: the hex share it sent the complainer
+type RevealRequest struct {
+	Recipient int    `json:"recipient"`
+	Share     string `json:"share"`
+}
+
+// DecryptionShareRequest represents a trustee's hex decryption factors and proofs, one per candidate
+type DecryptionShareRequest struct {
+	Factors []string                    `json:"factors"`
+	Proofs  []ballot.ChaumPedersenProof `json:"proofs"`
+}
+
+// Validate validates the ceremony request
+func (req *CeremonyRequest) Validate() error {
+	if len(req.Trustees) == 0 || len(req.Trustees) > MaxTrustees {
+		return fmt.Errorf("trustees must have between 1 and %d entries", MaxTrustees)
+	}
+	if req.Threshold < 1 || req.Threshold > len(req.Trustees) {
+		return fmt.Errorf("threshold must be between 1 and the number of trustees (%d)", len(req.Trustees))
+	}
+	for i, trustee := range req.Trustees {
+		if trustee.Name == "" {
+			return fmt.Errorf("name of trustee %d is required", i+1)
+		}
+	}
+	return nil
+}
+
+// Trustee returns the trustee with the given index
+func (c *Ceremony) Trustee(index int) (*Trustee, error) {
+	if index < 1 || index > len(c.Trustees) {
+		return nil, fmt.Errorf("trustee %d of election %s was not found", index, c.ElectionID)
+	}
+	return c.Trustees[index-1], nil
+}
+
+// Complaint returns the complaint of a trustee against a dealer, or nil if there is none
+func (c *Ceremony) Complaint(dealer, complainer int) *Complaint {
+	for _, complaint := range c.Complaints {
+		if complaint.Dealer == dealer && complaint.Complainer == complainer {
+			return complaint
+		}
+	}
+	return nil
+}
+
+// IsFinalized reports whether the ceremony produced the election key
+func (c *Ceremony) IsFinalized() bool {
+	return c.FinalizedAt != nil
+}
+
+// Qualified returns the dealers whose shares make up the election key: those that committed with a
+// proof of knowledge of their constant term, were not disqualified, and answered every complaint against them
+func (c *Ceremony) Qualified() []*Trustee {
+	qualified := []*Trustee{}
+	for _, trustee := range c.Trustees {
+		if trustee.CommittedAt == nil || trustee.CommitmentProof == nil || trustee.Disqualified {
+			continue
+		}
+		answered := true
+		for _, complaint := range c.Complaints {
+			if complaint.Dealer == trustee.Index && complaint.ResolvedAt == nil {
+				answered = false
+			}
+		}
+		if answered {
+			qualified = append(qualified, trustee)
+		}
+	}
+	return qualified
+}
+
+// ParseCommitments decodes the trustee's commitments
+func (t *Trustee) ParseCommitments() ([]*big.Int, error) {
+	return ParseElements(t.Commitments, "commitment")
+}
+
+// ParseVerificationKey decodes the trustee's verification key
+func (t *Trustee) ParseVerificationKey() (*big.Int, error) {
+	keys, err := ParseElements([]string{t.VerificationKey}, "verification key")
+	if err != nil {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+// ToPartialDecryption decodes the share's factors
+func (s *DecryptionShare) ToPartialDecryption() (*PartialDecryption, error) {
+	factors, err := ParseElements(s.Factors, "factor")
+	if err != nil {
+		return nil, err
+	}
+	return &PartialDecryption{TrusteeIndex: s.TrusteeIndex, Factors: factors, Proofs: s.Proofs}, nil
+}
+
+// ParseElements decodes hex group elements
+func ParseElements(values []string, name string) ([]*big.Int, error) {
+	elements := make([]*big.Int, len(values))
+	for i, value := range values {
+		data, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %d: must be valid hexadecimal: %v", name, i+1, err)
+		}
+		element, err := ballot.ElGamalGroup.DecodeElement(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %d: %v", name, i+1, err)
+		}
+		elements[i] = element
+	}
+	return elements, nil
+}
+
+// EncodeElement encodes a group element as hex
+func EncodeElement(element *big.Int) string {
+	return hex.EncodeToString(ballot.ElGamalGroup.EncodeElement(element))
+}
+
+// ParseShare decodes a hex share, an exponent below Q
+func ParseShare(value string) (*big.Int, error) {
+	data, err := hex.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid share: must be non-empty hexadecimal")
+	}
+	share := new(big.Int).SetBytes(data)
+	if share.Cmp(ballot.ElGamalGroup.Q) >= 0 {
+		return nil, fmt.Errorf("invalid share: must be below the group order")
+	}
+	return share, nil
+}
+
+// Repository defines the interface for trustee ceremony data operations
+type Repository interface {
+	CreateCeremony(ceremony *Ceremony) error
+	GetCeremony(electionID string) (*Ceremony, error)
+	SaveCommitments(trustee *Trustee) error
+	CreateComplaint(complaint *Complaint) error
+	ResolveComplaint(complaint *Complaint, disqualify bool) error
+	Finalize(ceremony *Ceremony, key *ballot.ElectionKey) error
+	CreateDecryptionShare(share *DecryptionShare) error
+	GetDecryptionShares(electionID string) ([]*DecryptionShare, error)
+}
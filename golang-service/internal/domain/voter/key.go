@@ -0,0 +1,70 @@
+package voter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Key is an Ed25519 public key a voter enrolled to sign encrypted ballots in an election.
+// A voter may enroll several keys per election; a revoked key no longer signs ballots.
+type Key struct {
+	KeyID      int        `json:"key_id" db:"key_id"`
+	VoterID    int        `json:"voter_id" db:"voter_id"`
+	ElectionID string     `json:"election_id" db:"election_id"`
+	PublicKey  string     `json:"public_key" db:"public_key"`
+	EnrolledAt time.Time  `json:"enrolled_at" db:"enrolled_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// KeyRequest represents the request payload for enrolling a voter key
+type KeyRequest struct {
+	ElectionID string `json:"election_id"`
+	PublicKey  string `json:"public_key"`
+}
+
+// KeysResponse represents the keys a voter enrolled
+type KeysResponse struct {
+	VoterID int    `json:"voter_id"`
+	Keys    []*Key `json:"keys"`
+}
+
+// Validate validates the key enrollment request and normalizes the public key to lowercase hex
+func (req *KeyRequest) Validate() error {
+	if req.ElectionID == "" {
+		return fmt.Errorf("election_id is required")
+	}
+
+	if req.PublicKey == "" {
+		return fmt.Errorf("public_key is required")
+	}
+	req.PublicKey = NormalizePublicKey(req.PublicKey)
+
+	return nil
+}
+
+// IsRevoked reports whether the key was revoked
+func (k *Key) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// NormalizePublicKey returns a hex public key in the lowercase form keys are enrolled in
+func NormalizePublicKey(publicKey string) string {
+	return strings.ToLower(strings.TrimPrefix(publicKey, "0x"))
+}
+
+// KeyRepository defines the interface for voter key data operations
+type KeyRepository interface {
+	Create(key *Key) error
+	GetByID(keyID int) (*Key, error)
+	GetByVoterID(voterID int, electionID string) ([]*Key, error)
+	GetActiveByPublicKey(electionID, publicKey string) (*Key, error)
+	Revoke(key *Key, revokedAt time.Time) error
+}
+
+// KeyService defines the interface for voter key business logic
+type KeyService interface {
+	EnrollKey(voterID int, req KeyRequest) (*Key, error)
+	GetVoterKeys(voterID int, electionID string) (*KeysResponse, error)
+	RevokeKey(voterID, keyID int) (*Key, error)
+}
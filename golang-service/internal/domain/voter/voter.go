@@ -2,6 +2,7 @@ package voter
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 )
@@ -48,6 +49,21 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// AlreadyVotedError reports a second ballot from a voter in an election, or a second weighted vote
+// if ElectionID is empty. Repositories return it when a uniqueness constraint refuses the insert,
+// so it holds even for requests that raced past the service's own check.
+type AlreadyVotedError struct {
+	VoterID    int
+	ElectionID string
+}
+
+func (e *AlreadyVotedError) Error() string {
+	if e.ElectionID == "" {
+		return fmt.Sprintf("voter with id: %d has already voted", e.VoterID)
+	}
+	return fmt.Sprintf("voter %d has already voted in election %s", e.VoterID, e.ElectionID)
+}
+
 // ValidateAge validates that the voter is at least 18 years old
 func (v *Voter) ValidateAge() error {
 	if v.Age < 18 {
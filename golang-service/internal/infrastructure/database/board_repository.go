@@ -0,0 +1,244 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/board"
+)
+
+// PostgresBoardRepository implements the board.Repository interface
+type PostgresBoardRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBoardRepository creates a new PostgreSQL bulletin board repository
+func NewPostgresBoardRepository(db *sql.DB) board.Repository {
+	return &PostgresBoardRepository{db: db}
+}
+
+const boardEntryColumns = `election_id, sequence, ballot_id, ballot_hash, prev_hash, hash, appended_at`
+
+const boardCheckpointColumns = `election_id, size, head_hash, public_key, signature, created_at`
+
+// GetHead retrieves the last entry of an election's board, or nil if the board is empty
+func (r *PostgresBoardRepository) GetHead(electionID string) (*board.Entry, error) {
+	return getBoardHead(r.db, electionID)
+}
+
+// GetEntries retrieves up to limit entries of an election's board with a sequence number above after
+func (r *PostgresBoardRepository) GetEntries(electionID string, after int64, limit int) ([]*board.Entry, error) {
+	query := `
+		SELECT ` + boardEntryColumns + `
+		FROM board_entries
+		WHERE election_id = $1 AND sequence > $2
+		ORDER BY sequence
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, electionID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query board entries: %v", err)
+	}
+	defer rows.Close()
+
+	entries := []*board.Entry{}
+	for rows.Next() {
+		entry, err := scanBoardEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan board entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating board entries: %v", err)
+	}
+
+	return entries, nil
+}
+
+// GetEntry retrieves the entry with the given sequence number
+func (r *PostgresBoardRepository) GetEntry(electionID string, sequence int64) (*board.Entry, error) {
+	query := `SELECT ` + boardEntryColumns + ` FROM board_entries WHERE election_id = $1 AND sequence = $2`
+
+	entry, err := scanBoardEntry(r.db.QueryRow(query, electionID, sequence))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("board entry %d of election %s was not found", sequence, electionID)
+		}
+		return nil, fmt.Errorf("failed to get board entry: %v", err)
+	}
+
+	return entry, nil
+}
+
+// CreateCheckpoint stores a signed checkpoint. A board is checkpointed at most once per size.
+func (r *PostgresBoardRepository) CreateCheckpoint(checkpoint *board.Checkpoint) error {
+	query := `
+		INSERT INTO board_checkpoints (` + boardCheckpointColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(query, checkpoint.ElectionID, checkpoint.Size, checkpoint.HeadHash,
+		checkpoint.PublicKey, checkpoint.Signature, checkpoint.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "board_checkpoints_pkey") {
+			return fmt.Errorf("board of election %s already has a checkpoint at size %d", checkpoint.ElectionID, checkpoint.Size)
+		}
+		return fmt.Errorf("failed to create board checkpoint: %v", err)
+	}
+
+	return nil
+}
+
+// GetCheckpoints retrieves every checkpoint of an election's board, oldest first
+func (r *PostgresBoardRepository) GetCheckpoints(electionID string) ([]*board.Checkpoint, error) {
+	query := `SELECT ` + boardCheckpointColumns + ` FROM board_checkpoints WHERE election_id = $1 ORDER BY size`
+
+	rows, err := r.db.Query(query, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query board checkpoints: %v", err)
+	}
+	defer rows.Close()
+
+	checkpoints := []*board.Checkpoint{}
+	for rows.Next() {
+		checkpoint, err := scanBoardCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan board checkpoint: %v", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating board checkpoints: %v", err)
+	}
+
+	return checkpoints, nil
+}
+
+// GetCheckpoint retrieves the checkpoint of an election's board at the given size
+func (r *PostgresBoardRepository) GetCheckpoint(electionID string, size int64) (*board.Checkpoint, error) {
+	query := `SELECT ` + boardCheckpointColumns + ` FROM board_checkpoints WHERE election_id = $1 AND size = $2`
+
+	checkpoint, err := scanBoardCheckpoint(r.db.QueryRow(query, electionID, size))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("checkpoint at size %d of election %s was not found", size, electionID)
+		}
+		return nil, fmt.Errorf("failed to get board checkpoint: %v", err)
+	}
+
+	return checkpoint, nil
+}
+
+// GetUncheckpointedElections retrieves the elections whose board has entries after its latest checkpoint
+func (r *PostgresBoardRepository) GetUncheckpointedElections() ([]string, error) {
+	query := `
+		SELECT e.election_id
+		FROM board_entries e
+		GROUP BY e.election_id
+		HAVING MAX(e.sequence) > COALESCE(
+			(SELECT MAX(c.size) FROM board_checkpoints c WHERE c.election_id = e.election_id), 0)
+		ORDER BY e.election_id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query uncheckpointed boards: %v", err)
+	}
+	defer rows.Close()
+
+	var electionIDs []string
+	for rows.Next() {
+		var electionID string
+		if err := rows.Scan(&electionID); err != nil {
+			return nil, fmt.Errorf("failed to scan uncheckpointed board: %v", err)
+		}
+		electionIDs = append(electionIDs, electionID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating uncheckpointed boards: %v", err)
+	}
+
+	return electionIDs, nil
+}
+
+// appendBoardEntry appends an accepted ballot to its election's board inside the transaction that
+// stores it. Appends to one election's board are serialized by a transaction-level advisory lock,
+// so every entry chains to the entry before it.
+func appendBoardEntry(tx *sql.Tx, encryptedBallot *ballot.EncryptedBallot) error {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('board:' || $1))`, encryptedBallot.ElectionID); err != nil {
+		return fmt.Errorf("failed to lock board: %v", err)
+	}
+
+	head, err := getBoardHead(tx, encryptedBallot.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	entry, err := board.NewEntry(head, encryptedBallot, encryptedBallot.AnchoredAt)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO board_entries (` + boardEntryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.Exec(query, entry.ElectionID, entry.Sequence, entry.BallotID, entry.BallotHash,
+		entry.PrevHash, entry.Hash, entry.AppendedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append board entry: %v", err)
+	}
+
+	return nil
+}
+
+// queryRower is implemented by *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// getBoardHead retrieves the last entry of an election's board, or nil if the board is empty
+func getBoardHead(q queryRower, electionID string) (*board.Entry, error) {
+	query := `
+		SELECT ` + boardEntryColumns + `
+		FROM board_entries
+		WHERE election_id = $1
+		ORDER BY sequence DESC
+		LIMIT 1`
+
+	entry, err := scanBoardEntry(q.QueryRow(query, electionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get board head: %v", err)
+	}
+
+	return entry, nil
+}
+
+// scanBoardEntry reads one board entry row
+func scanBoardEntry(row rowScanner) (*board.Entry, error) {
+	entry := &board.Entry{}
+	err := row.Scan(&entry.ElectionID, &entry.Sequence, &entry.BallotID, &entry.BallotHash,
+		&entry.PrevHash, &entry.Hash, &entry.AppendedAt)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// scanBoardCheckpoint reads one board checkpoint row
+func scanBoardCheckpoint(row rowScanner) (*board.Checkpoint, error) {
+	checkpoint := &board.Checkpoint{}
+	err := row.Scan(&checkpoint.ElectionID, &checkpoint.Size, &checkpoint.HeadHash,
+		&checkpoint.PublicKey, &checkpoint.Signature, &checkpoint.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/lib/pq"
+)
+
+// PostgresCandidateRepository implements the candidate.Repository interface
+type PostgresCandidateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCandidateRepository creates a new PostgreSQL candidate repository
+func NewPostgresCandidateRepository(db *sql.DB) candidate.Repository {
+	return &PostgresCandidateRepository{db: db}
+}
+
+// Create inserts a new candidate into the database
+func (r *PostgresCandidateRepository) Create(c *candidate.Candidate) error {
+	query := `
+		INSERT INTO candidate (name, party)
+		VALUES ($1, $2)
+		RETURNING candidate_id, votes_count
+	`
+
+	err := r.db.QueryRow(query, c.Name, c.Party).Scan(&c.CandidateID, &c.VotesCount)
+	if err != nil {
+		return fmt.Errorf("failed to create candidate: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a candidate by their ID
+func (r *PostgresCandidateRepository) GetByID(candidateID int) (*candidate.Candidate, error) {
+	query := `
+		SELECT candidate_id, name, party, votes_count
+		FROM candidate
+		WHERE candidate_id = $1
+	`
+
+	c := &candidate.Candidate{}
+	err := r.db.QueryRow(query, candidateID).Scan(&c.CandidateID, &c.Name, &c.Party, &c.VotesCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("candidate with id: %d was not found", candidateID)
+		}
+		return nil, fmt.Errorf("failed to get candidate: %w", err)
+	}
+
+	return c, nil
+}
+
+// GetAll retrieves all candidates, optionally filtered by party
+func (r *PostgresCandidateRepository) GetAll(party string) ([]*candidate.Candidate, error) {
+	query := `
+		SELECT candidate_id, name, party, votes_count
+		FROM candidate
+		WHERE $1 = '' OR party = $1
+		ORDER BY candidate_id
+	`
+
+	rows, err := r.db.Query(query, party)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*candidate.Candidate
+	for rows.Next() {
+		c := &candidate.Candidate{}
+		err := rows.Scan(&c.CandidateID, &c.Name, &c.Party, &c.VotesCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// Update updates an existing candidate's name and party
+func (r *PostgresCandidateRepository) Update(c *candidate.Candidate) error {
+	query := `
+		UPDATE candidate
+		SET name = $2, party = $3
+		WHERE candidate_id = $1
+		RETURNING votes_count
+	`
+
+	err := r.db.QueryRow(query, c.CandidateID, c.Name, c.Party).Scan(&c.VotesCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("candidate with id: %d was not found", c.CandidateID)
+		}
+		return fmt.Errorf("failed to update candidate: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a candidate from the database
+func (r *PostgresCandidateRepository) Delete(candidateID int) error {
+	query := `DELETE FROM candidate WHERE candidate_id = $1`
+
+	result, err := r.db.Exec(query, candidateID)
+	if err != nil {
+		// Votes, ballot rankings, and election slates restrict candidate deletion
+		if strings.Contains(err.Error(), "foreign key") {
+			return fmt.Errorf("candidate with id: %d is referenced by votes, ballots, or elections", candidateID)
+		}
+		return fmt.Errorf("failed to delete candidate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("candidate with id: %d was not found", candidateID)
+	}
+
+	return nil
+}
+
+// FindMissing returns the given candidate IDs that do not exist, in input order
+func (r *PostgresCandidateRepository) FindMissing(candidateIDs []int) ([]int, error) {
+	query := `SELECT candidate_id FROM candidate WHERE candidate_id = ANY($1)`
+
+	ids := make([]int64, len(candidateIDs))
+	for i, candidateID := range candidateIDs {
+		ids[i] = int64(candidateID)
+	}
+
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check candidate existence: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[int]bool)
+	for rows.Next() {
+		var candidateID int
+		if err := rows.Scan(&candidateID); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		existing[candidateID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidates: %w", err)
+	}
+
+	missing := []int{}
+	for _, candidateID := range candidateIDs {
+		if !existing[candidateID] {
+			missing = append(missing, candidateID)
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+)
+
+// CardinalBallotPostgresRepository implements the CardinalBallotRepository interface
+type CardinalBallotPostgresRepository struct {
+	db  *sql.DB
+	uow *UnitOfWork
+}
+
+// NewCardinalBallotRepository creates a new approval and score ballot repository
+func NewCardinalBallotRepository(db *sql.DB) ballot.CardinalBallotRepository {
+	return &CardinalBallotPostgresRepository{db: db, uow: NewUnitOfWork(db)}
+}
+
+// Create stores a new cardinal ballot with its scores in a transaction
+func (r *CardinalBallotPostgresRepository) Create(cardinalBallot *ballot.CardinalBallot, scores []ballot.BallotScore) error {
+	return r.create(cardinalBallot, scores, false)
+}
+
+// Replace stores a cardinal ballot as the voter's current ballot in its election, marking the
+// voter's earlier accepted ballots of the same type superseded in the same transaction
+func (r *CardinalBallotPostgresRepository) Replace(cardinalBallot *ballot.CardinalBallot, scores []ballot.BallotScore) error {
+	return r.create(cardinalBallot, scores, true)
+}
+
+// create stores a cardinal ballot with its scores and marks the voter as having voted in one transaction,
+// superseding the voter's earlier ballots if supersede is set. A second ballot that the unique constraint
+// refuses is returned as a *voter.AlreadyVotedError.
+func (r *CardinalBallotPostgresRepository) create(cardinalBallot *ballot.CardinalBallot, scores []ballot.BallotScore, supersede bool) error {
+	return r.uow.Do(func(tx *sql.Tx) error {
+		// Supersede the voter's current ballot. The advisory lock serializes a voter's ballots in the
+		// election, so two concurrent ballots cannot both stay accepted.
+		if supersede {
+			lockQuery := `SELECT pg_advisory_xact_lock(hashtext('cardinal:' || $1 || ':' || $2::text))`
+			if _, err := tx.Exec(lockQuery, cardinalBallot.ElectionID, cardinalBallot.VoterID); err != nil {
+				return fmt.Errorf("failed to lock voter ballots: %v", err)
+			}
+
+			supersedeQuery := `
+				UPDATE cardinal_ballots SET status = $4
+				WHERE election_id = $1 AND voter_id = $2 AND ballot_type = $3 AND status = $5`
+			_, err := tx.Exec(
+				supersedeQuery,
+				cardinalBallot.ElectionID,
+				cardinalBallot.VoterID,
+				cardinalBallot.BallotType,
+				ballot.BallotStatusSuperseded,
+				ballot.BallotStatusAccepted,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to supersede %s ballots: %v", cardinalBallot.BallotType, err)
+			}
+		}
+
+		// Insert cardinal ballot
+		ballotQuery := `
+			INSERT INTO cardinal_ballots
+			(ballot_id, election_id, voter_id, ballot_type, timestamp, status)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+
+		_, err := tx.Exec(
+			ballotQuery,
+			cardinalBallot.BallotID,
+			cardinalBallot.ElectionID,
+			cardinalBallot.VoterID,
+			cardinalBallot.BallotType,
+			cardinalBallot.Timestamp,
+			cardinalBallot.Status,
+		)
+		if err != nil {
+			// A taken ballot ID is retried by the caller with a new one
+			if strings.Contains(err.Error(), "cardinal_ballots_pkey") {
+				return fmt.Errorf("%w: %s", ballot.ErrBallotIDConflict, cardinalBallot.BallotID)
+			}
+			// A voter has one accepted ballot per election
+			if strings.Contains(err.Error(), "cardinal_ballots_election_id_voter_id_key") {
+				return &voter.AlreadyVotedError{VoterID: cardinalBallot.VoterID, ElectionID: cardinalBallot.ElectionID}
+			}
+			return fmt.Errorf("failed to create %s ballot: %v", cardinalBallot.BallotType, err)
+		}
+
+		// Insert ballot scores
+		scoreQuery := `
+			INSERT INTO ballot_scores
+			(ballot_id, candidate_id, score)
+			VALUES ($1, $2, $3)`
+
+		for _, score := range scores {
+			if _, err := tx.Exec(scoreQuery, score.BallotID, score.CandidateID, score.Score); err != nil {
+				return fmt.Errorf("failed to create ballot score: %v", err)
+			}
+		}
+
+		// The voter is only marked as having voted if the ballot is stored
+		return markVoted(tx, cardinalBallot.VoterID)
+	})
+}
+
+// GetByBallotID retrieves a cardinal ballot with its scores by ballot ID
+func (r *CardinalBallotPostgresRepository) GetByBallotID(ballotID string) (*ballot.CardinalBallot, []ballot.BallotScore, error) {
+	ballotQuery := `
+		SELECT ballot_id, election_id, voter_id, ballot_type, timestamp, status
+		FROM cardinal_ballots
+		WHERE ballot_id = $1`
+
+	var cardinalBallot ballot.CardinalBallot
+	err := r.db.QueryRow(ballotQuery, ballotID).Scan(
+		&cardinalBallot.BallotID,
+		&cardinalBallot.ElectionID,
+		&cardinalBallot.VoterID,
+		&cardinalBallot.BallotType,
+		&cardinalBallot.Timestamp,
+		&cardinalBallot.Status,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("cardinal ballot not found: %s", ballotID)
+		}
+		return nil, nil, fmt.Errorf("failed to get cardinal ballot: %v", err)
+	}
+
+	scoresQuery := `
+		SELECT id, ballot_id, candidate_id, score
+		FROM ballot_scores
+		WHERE ballot_id = $1
+		ORDER BY candidate_id ASC`
+
+	rows, err := r.db.Query(scoresQuery, ballotID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query ballot scores: %v", err)
+	}
+	defer rows.Close()
+
+	scores := []ballot.BallotScore{}
+	for rows.Next() {
+		var score ballot.BallotScore
+		if err := rows.Scan(&score.ID, &score.BallotID, &score.CandidateID, &score.Score); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan ballot score: %v", err)
+		}
+		scores = append(scores, score)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating ballot scores: %v", err)
+	}
+
+	return &cardinalBallot, scores, nil
+}
+
+// GetByElectionID retrieves the counted cardinal ballots of a type with their scores for an election;
+// superseded ballots are left out
+func (r *CardinalBallotPostgresRepository) GetByElectionID(electionID, ballotType string) ([]ballot.CardinalBallotWithScores, error) {
+	query := `
+		SELECT cb.ballot_id, cb.election_id, cb.voter_id, cb.ballot_type, cb.timestamp, cb.status,
+			   bs.id, bs.candidate_id, bs.score
+		FROM cardinal_ballots cb
+		LEFT JOIN ballot_scores bs ON cb.ballot_id = bs.ballot_id
+		WHERE cb.election_id = $1 AND cb.ballot_type = $2 AND cb.status = $3
+		ORDER BY cb.ballot_id, bs.candidate_id ASC`
+
+	rows, err := r.db.Query(query, electionID, ballotType, ballot.BallotStatusAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cardinal ballots: %v", err)
+	}
+	defer rows.Close()
+
+	results := []ballot.CardinalBallotWithScores{}
+	for rows.Next() {
+		var ballotData ballot.CardinalBallot
+		var scoreID sql.NullInt32
+		var candidateID sql.NullInt32
+		var score sql.NullInt32
+
+		err := rows.Scan(
+			&ballotData.BallotID,
+			&ballotData.ElectionID,
+			&ballotData.VoterID,
+			&ballotData.BallotType,
+			&ballotData.Timestamp,
+			&ballotData.Status,
+			&scoreID,
+			&candidateID,
+			&score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cardinal ballot: %v", err)
+		}
+
+		// Rows arrive grouped by ballot
+		if len(results) == 0 || results[len(results)-1].Ballot.BallotID != ballotData.BallotID {
+			results = append(results, ballot.CardinalBallotWithScores{
+				Ballot: ballotData,
+				Scores: []ballot.BallotScore{},
+			})
+		}
+
+		// Add score if it exists (LEFT JOIN returns null scores for empty approval ballots)
+		if scoreID.Valid {
+			current := &results[len(results)-1]
+			current.Scores = append(current.Scores, ballot.BallotScore{
+				ID:          int(scoreID.Int32),
+				BallotID:    ballotData.BallotID,
+				CandidateID: int(candidateID.Int32),
+				Score:       int(score.Int32),
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cardinal ballots: %v", err)
+	}
+
+	return results, nil
+}
+
+// GetByVoterID retrieves all cardinal ballots of a type cast by a voter
+func (r *CardinalBallotPostgresRepository) GetByVoterID(voterID int, ballotType string) ([]*ballot.CardinalBallot, error) {
+	query := `
+		SELECT ballot_id, election_id, voter_id, ballot_type, timestamp, status
+		FROM cardinal_ballots
+		WHERE voter_id = $1 AND ballot_type = $2
+		ORDER BY timestamp DESC`
+
+	rows, err := r.db.Query(query, voterID, ballotType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cardinal ballots: %v", err)
+	}
+	defer rows.Close()
+
+	var ballots []*ballot.CardinalBallot
+	for rows.Next() {
+		var cardinalBallot ballot.CardinalBallot
+		err := rows.Scan(
+			&cardinalBallot.BallotID,
+			&cardinalBallot.ElectionID,
+			&cardinalBallot.VoterID,
+			&cardinalBallot.BallotType,
+			&cardinalBallot.Timestamp,
+			&cardinalBallot.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cardinal ballot: %v", err)
+		}
+		ballots = append(ballots, &cardinalBallot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cardinal ballots: %v", err)
+	}
+
+	return ballots, nil
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+)
+
+// ElectionKeyPostgresRepository implements the ElectionKeyRepository interface
+type ElectionKeyPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewElectionKeyRepository creates a new election key repository
+func NewElectionKeyRepository(db *sql.DB) ballot.ElectionKeyRepository {
+	return &ElectionKeyPostgresRepository{db: db}
+}
+
+// Create stores the public key of an election; the secret key is never stored. An election has a single key.
+func (r *ElectionKeyPostgresRepository) Create(key *ballot.ElectionKey) error {
+	query := `
+		INSERT INTO election_keys (election_id, public_key, created_at)
+		VALUES ($1, $2, $3)`
+
+	key.CreatedAt = time.Now()
+
+	_, err := r.db.Exec(query, key.ElectionID, key.PublicKey, key.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "election_keys_pkey") {
+			return fmt.Errorf("election %s already has a key", key.ElectionID)
+		}
+		return fmt.Errorf("failed to create election key: %v", err)
+	}
+
+	return nil
+}
+
+// GetByElectionID retrieves the public key of an election, or nil if it has none
+func (r *ElectionKeyPostgresRepository) GetByElectionID(electionID string) (*ballot.ElectionKey, error) {
+	query := `
+		SELECT election_id, public_key, threshold, created_at
+		FROM election_keys
+		WHERE election_id = $1`
+
+	key := &ballot.ElectionKey{Group: ballot.ElGamalGroupName}
+	err := r.db.QueryRow(query, electionID).Scan(&key.ElectionID, &key.PublicKey, &key.Threshold, &key.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get election key: %v", err)
+	}
+
+	return key, nil
+}
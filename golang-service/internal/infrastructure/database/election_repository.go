@@ -0,0 +1,411 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/lib/pq"
+)
+
+// PostgresElectionRepository implements the election.Repository interface
+type PostgresElectionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresElectionRepository creates a new PostgreSQL election repository
+func NewPostgresElectionRepository(db *sql.DB) election.Repository {
+	return &PostgresElectionRepository{db: db}
+}
+
+// Create inserts a new election with its candidate slate in a transaction
+func (r *PostgresElectionRepository) Create(e *election.Election) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO elections (election_id, title, ballot_type, max_score, opens_at, closes_at, phase, created_at, updated_at, method, method_options, strict_signatures, max_selections, secret_ballot, revote_policy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	methodOptions, err := marshalMethodOptions(e.MethodOptions)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	e.CreatedAt = now
+	e.UpdatedAt = now
+
+	_, err = tx.Exec(query, e.ElectionID, e.Title, e.BallotType, e.MaxScore, e.OpensAt, e.ClosesAt, e.Phase, e.CreatedAt, e.UpdatedAt, e.Method, methodOptions, e.StrictSignatures, e.MaxSelections, e.SecretBallot, e.RevotePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to create election: %w", err)
+	}
+
+	if err = insertElectionCandidates(tx, e.ElectionID, e.CandidateIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an election with its candidate slate by ID
+func (r *PostgresElectionRepository) GetByID(electionID string) (*election.Election, error) {
+	query := `
+		SELECT election_id, title, ballot_type, max_score, opens_at, closes_at, phase, created_at, updated_at, method, method_options, strict_signatures, max_selections, secret_ballot, revote_policy
+		FROM elections
+		WHERE election_id = $1
+	`
+
+	e, err := scanElection(r.db.QueryRow(query, electionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("election with id: %s was not found", electionID)
+		}
+		return nil, fmt.Errorf("failed to get election: %w", err)
+	}
+
+	candidateIDs, err := r.getCandidateIDs(electionID)
+	if err != nil {
+		return nil, err
+	}
+	e.CandidateIDs = candidateIDs
+
+	return e, nil
+}
+
+// GetAll retrieves all elections
+func (r *PostgresElectionRepository) GetAll() ([]*election.Election, error) {
+	query := `
+		SELECT election_id, title, ballot_type, max_score, opens_at, closes_at, phase, created_at, updated_at, method, method_options, strict_signatures, max_selections, secret_ballot, revote_policy
+		FROM elections
+		ORDER BY opens_at, election_id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all elections: %w", err)
+	}
+
+	return r.scanElections(rows)
+}
+
+// GetByPhase retrieves all elections currently in any of the given phases
+func (r *PostgresElectionRepository) GetByPhase(phases ...string) ([]*election.Election, error) {
+	query := `
+		SELECT election_id, title, ballot_type, max_score, opens_at, closes_at, phase, created_at, updated_at, method, method_options, strict_signatures, max_selections, secret_ballot, revote_policy
+		FROM elections
+		WHERE phase = ANY($1)
+		ORDER BY opens_at, election_id
+	`
+
+	rows, err := r.db.Query(query, pq.Array(phases))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get elections by phase: %w", err)
+	}
+
+	return r.scanElections(rows)
+}
+
+// Update updates an existing election and replaces its candidate slate.
+// The phase is only changed through SaveTransition.
+func (r *PostgresElectionRepository) Update(e *election.Election) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		UPDATE elections
+		SET title = $2, ballot_type = $3, max_score = $4, opens_at = $5, closes_at = $6, updated_at = $7,
+			method = $8, method_options = $9, strict_signatures = $10, max_selections = $11, secret_ballot = $12, revote_policy = $13
+		WHERE election_id = $1
+	`
+
+	methodOptions, err := marshalMethodOptions(e.MethodOptions)
+	if err != nil {
+		return err
+	}
+
+	e.UpdatedAt = time.Now()
+	result, err := tx.Exec(query, e.ElectionID, e.Title, e.BallotType, e.MaxScore, e.OpensAt, e.ClosesAt, e.UpdatedAt, e.Method, methodOptions, e.StrictSignatures, e.MaxSelections, e.SecretBallot, e.RevotePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to update election: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		err = fmt.Errorf("election with id: %s was not found", e.ElectionID)
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM election_candidates WHERE election_id = $1`, e.ElectionID); err != nil {
+		return fmt.Errorf("failed to clear election candidates: %w", err)
+	}
+
+	if err = insertElectionCandidates(tx, e.ElectionID, e.CandidateIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an election and its candidate slate
+func (r *PostgresElectionRepository) Delete(electionID string) error {
+	query := `DELETE FROM elections WHERE election_id = $1`
+
+	result, err := r.db.Exec(query, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete election: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("election with id: %s was not found", electionID)
+	}
+
+	return nil
+}
+
+// ExistsByID checks if an election exists with the given ID
+func (r *PostgresElectionRepository) ExistsByID(electionID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM elections WHERE election_id = $1)`
+
+	var exists bool
+	err := r.db.QueryRow(query, electionID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check election existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SaveTransition stores a phase change of an election together with its transition record.
+// The update only applies if the election is still in the transition's starting phase,
+// so concurrent transitions (e.g. the scheduler and an administrator) cannot both succeed.
+func (r *PostgresElectionRepository) SaveTransition(e *election.Election, transition *election.Transition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	updateQuery := `
+		UPDATE elections
+		SET phase = $2, updated_at = $3
+		WHERE election_id = $1 AND phase = $4
+	`
+
+	result, err := tx.Exec(updateQuery, e.ElectionID, transition.ToPhase, transition.TransitionedAt, transition.FromPhase)
+	if err != nil {
+		return fmt.Errorf("failed to update election phase: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		err = fmt.Errorf("invalid phase transition for election %s: election is no longer in phase %s", e.ElectionID, transition.FromPhase)
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO election_transitions (election_id, from_phase, to_phase, actor, transitioned_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(insertQuery, transition.ElectionID, transition.FromPhase, transition.ToPhase, transition.Actor, transition.TransitionedAt).Scan(&transition.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record election transition: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	e.UpdatedAt = transition.TransitionedAt
+	return nil
+}
+
+// GetTransitions retrieves the phase history of an election in chronological order
+func (r *PostgresElectionRepository) GetTransitions(electionID string) ([]*election.Transition, error) {
+	query := `
+		SELECT id, election_id, from_phase, to_phase, actor, transitioned_at
+		FROM election_transitions
+		WHERE election_id = $1
+		ORDER BY transitioned_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election transitions: %w", err)
+	}
+	defer rows.Close()
+
+	transitions := []*election.Transition{}
+	for rows.Next() {
+		t := &election.Transition{}
+		err := rows.Scan(&t.ID, &t.ElectionID, &t.FromPhase, &t.ToPhase, &t.Actor, &t.TransitionedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan election transition: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating election transitions: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// scanElections reads election rows and loads each election's candidate slate
+func (r *PostgresElectionRepository) scanElections(rows *sql.Rows) ([]*election.Election, error) {
+	defer rows.Close()
+
+	var elections []*election.Election
+	for rows.Next() {
+		e, err := scanElection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan election: %w", err)
+		}
+		elections = append(elections, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating elections: %w", err)
+	}
+	rows.Close()
+
+	for _, e := range elections {
+		candidateIDs, err := r.getCandidateIDs(e.ElectionID)
+		if err != nil {
+			return nil, err
+		}
+		e.CandidateIDs = candidateIDs
+	}
+
+	return elections, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanElection reads one election row, decoding its official method options
+func scanElection(row rowScanner) (*election.Election, error) {
+	e := &election.Election{}
+	var methodOptions []byte
+	err := row.Scan(&e.ElectionID, &e.Title, &e.BallotType, &e.MaxScore, &e.OpensAt, &e.ClosesAt, &e.Phase, &e.CreatedAt, &e.UpdatedAt, &e.Method, &methodOptions, &e.StrictSignatures, &e.MaxSelections, &e.SecretBallot, &e.RevotePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	e.MethodOptions = map[string]string{}
+	if len(methodOptions) > 0 {
+		if err := json.Unmarshal(methodOptions, &e.MethodOptions); err != nil {
+			return nil, fmt.Errorf("failed to decode method options: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// marshalMethodOptions encodes the official method options for the JSONB column.
+// The JSON is passed as a string since lib/pq would send a []byte as bytea.
+func marshalMethodOptions(options map[string]string) (string, error) {
+	if options == nil {
+		options = map[string]string{}
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode method options: %w", err)
+	}
+	return string(data), nil
+}
+
+// getCandidateIDs retrieves the candidate slate of an election in ballot order
+func (r *PostgresElectionRepository) getCandidateIDs(electionID string) ([]int, error) {
+	query := `
+		SELECT candidate_id
+		FROM election_candidates
+		WHERE election_id = $1
+		ORDER BY ballot_position ASC
+	`
+
+	rows, err := r.db.Query(query, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidateIDs := []int{}
+	for rows.Next() {
+		var candidateID int
+		if err := rows.Scan(&candidateID); err != nil {
+			return nil, fmt.Errorf("failed to scan election candidate: %w", err)
+		}
+		candidateIDs = append(candidateIDs, candidateID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating election candidates: %w", err)
+	}
+
+	return candidateIDs, nil
+}
+
+// insertElectionCandidates stores the candidate slate of an election in ballot order
+func insertElectionCandidates(tx *sql.Tx, electionID string, candidateIDs []int) error {
+	query := `
+		INSERT INTO election_candidates (election_id, candidate_id, ballot_position)
+		VALUES ($1, $2, $3)
+	`
+
+	for i, candidateID := range candidateIDs {
+		if _, err := tx.Exec(query, electionID, candidateID, i+1); err != nil {
+			return fmt.Errorf("failed to add candidate %d to election: %w", candidateID, err)
+		}
+	}
+
+	return nil
+}
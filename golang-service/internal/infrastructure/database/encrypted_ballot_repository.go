@@ -6,54 +6,78 @@ import (
 	"strings"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
 // EncryptedBallotPostgresRepository implements the EncryptedBallotRepository interface
 type EncryptedBallotPostgresRepository struct {
-	db *sql.DB
+	db  *sql.DB
+	uow *UnitOfWork
 }
 
 // NewEncryptedBallotRepository creates a new encrypted ballot repository
 func NewEncryptedBallotRepository(db *sql.DB) ballot.EncryptedBallotRepository {
-	return &EncryptedBallotPostgresRepository{db: db}
+	return &EncryptedBallotPostgresRepository{db: db, uow: NewUnitOfWork(db)}
 }
 
-// Create stores a new encrypted ballot
+// Create stores a new encrypted ballot and, if it was accepted, appends it to the election's
+// bulletin board and marks the voter as having voted in the same transaction. A second accepted
+// ballot from the voter in the election is returned as a *voter.AlreadyVotedError.
 func (r *EncryptedBallotPostgresRepository) Create(encryptedBallot *ballot.EncryptedBallot) error {
-	query := `
-		INSERT INTO encrypted_ballots 
-		(ballot_id, election_id, voter_id, ciphertext, zk_proof, voter_pubkey, nullifier, signature, status, anchored_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
-
-	_, err := r.db.Exec(
-		query,
-		encryptedBallot.BallotID,
-		encryptedBallot.ElectionID,
-		encryptedBallot.VoterID,
-		encryptedBallot.Ciphertext,
-		encryptedBallot.ZKProof,
-		encryptedBallot.VoterPubkey,
-		encryptedBallot.Nullifier,
-		encryptedBallot.Signature,
-		encryptedBallot.Status,
-		encryptedBallot.AnchoredAt,
-	)
-	if err != nil {
-		// Check for unique constraint violation on nullifier (double voting prevention)
-		if strings.Contains(err.Error(), "nullifier") && strings.Contains(err.Error(), "duplicate") {
-			return fmt.Errorf("duplicate nullifier: ballot already submitted for this voter")
+	return r.uow.Do(func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO encrypted_ballots 
+			(ballot_id, election_id, voter_id, ciphertext, zk_proof, voter_pubkey, nullifier, signature, status, tracking_code, anchored_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)`
+
+		_, err := tx.Exec(
+			query,
+			encryptedBallot.BallotID,
+			encryptedBallot.ElectionID,
+			encryptedBallot.VoterID,
+			encryptedBallot.Ciphertext,
+			encryptedBallot.ZKProof,
+			encryptedBallot.VoterPubkey,
+			encryptedBallot.Nullifier,
+			encryptedBallot.Signature,
+			encryptedBallot.Status,
+			encryptedBallot.TrackingCode,
+			encryptedBallot.AnchoredAt,
+		)
+		if err != nil {
+			// A taken ballot ID is retried by the caller with a new one, which also derives a new tracking code
+			if strings.Contains(err.Error(), "encrypted_ballots_pkey") || strings.Contains(err.Error(), "encrypted_ballots_tracking_code_key") {
+				return fmt.Errorf("%w: %s", ballot.ErrBallotIDConflict, encryptedBallot.BallotID)
+			}
+			// A voter has one accepted ballot per election, whatever nullifier it is cast under
+			if strings.Contains(err.Error(), "encrypted_ballots_election_id_voter_id_key") {
+				return &voter.AlreadyVotedError{VoterID: encryptedBallot.VoterID, ElectionID: encryptedBallot.ElectionID}
+			}
+			// Check for unique constraint violation on nullifier (double voting prevention)
+			if strings.Contains(err.Error(), "nullifier") && strings.Contains(err.Error(), "duplicate") {
+				return fmt.Errorf("duplicate nullifier: ballot already submitted for this voter")
+			}
+			return fmt.Errorf("failed to create encrypted ballot: %v", err)
+		}
+
+		if encryptedBallot.Status != ballot.BallotStatusAccepted {
+			return nil
 		}
-		return fmt.Errorf("failed to create encrypted ballot: %v", err)
-	}
 
-	return nil
+		if err := appendBoardEntry(tx, encryptedBallot); err != nil {
+			return err
+		}
+
+		// The voter is only marked as having voted if the ballot is stored
+		return markVoted(tx, encryptedBallot.VoterID)
+	})
 }
 
 // GetByBallotID retrieves an encrypted ballot by ballot ID
 func (r *EncryptedBallotPostgresRepository) GetByBallotID(ballotID string) (*ballot.EncryptedBallot, error) {
 	query := `
 		SELECT ballot_id, election_id, voter_id, ciphertext, zk_proof, voter_pubkey, 
-			   nullifier, signature, status, anchored_at
+			   nullifier, signature, status, COALESCE(tracking_code, ''), anchored_at
 		FROM encrypted_ballots
 		WHERE ballot_id = $1`
 
@@ -70,6 +94,7 @@ func (r *EncryptedBallotPostgresRepository) GetByBallotID(ballotID string) (*bal
 		&encryptedBallot.Nullifier,
 		&encryptedBallot.Signature,
 		&encryptedBallot.Status,
+		&encryptedBallot.TrackingCode,
 		&encryptedBallot.AnchoredAt,
 	)
 	if err != nil {
@@ -82,11 +107,24 @@ func (r *EncryptedBallotPostgresRepository) GetByBallotID(ballotID string) (*bal
 	return &encryptedBallot, nil
 }
 
+// HasVoted checks if a voter has an accepted encrypted ballot in an election
+func (r *EncryptedBallotPostgresRepository) HasVoted(electionID string, voterID int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM encrypted_ballots WHERE election_id = $1 AND voter_id = $2 AND status = $3)`
+
+	var exists bool
+	err := r.db.QueryRow(query, electionID, voterID, ballot.BallotStatusAccepted).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existing encrypted ballots: %v", err)
+	}
+
+	return exists, nil
+}
+
 // GetByNullifier retrieves an encrypted ballot by nullifier
 func (r *EncryptedBallotPostgresRepository) GetByNullifier(nullifier string) (*ballot.EncryptedBallot, error) {
 	query := `
 		SELECT ballot_id, election_id, voter_id, ciphertext, zk_proof, voter_pubkey, 
-			   nullifier, signature, status, anchored_at
+			   nullifier, signature, status, COALESCE(tracking_code, ''), anchored_at
 		FROM encrypted_ballots
 		WHERE nullifier = $1`
 
@@ -103,6 +141,7 @@ func (r *EncryptedBallotPostgresRepository) GetByNullifier(nullifier string) (*b
 		&encryptedBallot.Nullifier,
 		&encryptedBallot.Signature,
 		&encryptedBallot.Status,
+		&encryptedBallot.TrackingCode,
 		&encryptedBallot.AnchoredAt,
 	)
 	if err != nil {
@@ -119,7 +158,7 @@ func (r *EncryptedBallotPostgresRepository) GetByNullifier(nullifier string) (*b
 func (r *EncryptedBallotPostgresRepository) GetByElectionID(electionID string) ([]*ballot.EncryptedBallot, error) {
 	query := `
 		SELECT ballot_id, election_id, voter_id, ciphertext, zk_proof, voter_pubkey, 
-			   nullifier, signature, status, anchored_at
+			   nullifier, signature, status, COALESCE(tracking_code, ''), anchored_at
 		FROM encrypted_ballots
 		WHERE election_id = $1
 		ORDER BY anchored_at ASC`
@@ -143,6 +182,7 @@ func (r *EncryptedBallotPostgresRepository) GetByElectionID(electionID string) (
 			&encryptedBallot.Nullifier,
 			&encryptedBallot.Signature,
 			&encryptedBallot.Status,
+			&encryptedBallot.TrackingCode,
 			&encryptedBallot.AnchoredAt,
 		)
 		if err != nil {
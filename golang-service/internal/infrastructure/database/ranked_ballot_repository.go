@@ -3,74 +3,329 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/lib/pq"
 )
 
 // RankedBallotPostgresRepository implements the RankedBallotRepository interface
 type RankedBallotPostgresRepository struct {
-	db *sql.DB
+	db  *sql.DB
+	uow *UnitOfWork
 }
 
 // NewRankedBallotRepository creates a new ranked ballot repository
 func NewRankedBallotRepository(db *sql.DB) ballot.RankedBallotRepository {
-	return &RankedBallotPostgresRepository{db: db}
+	return &RankedBallotPostgresRepository{db: db, uow: NewUnitOfWork(db)}
 }
 
 // Create stores a new ranked ballot with its rankings in a transaction
 func (r *RankedBallotPostgresRepository) Create(rankedBallot *ballot.RankedBallot, rankings []ballot.BallotRanking) error {
-	// Start transaction
-	tx, err := r.db.Begin()
+	return r.create(rankedBallot, rankings, nil, false)
+}
+
+// CreateSecret stores a ranked ballot of a secret-ballot election, which has no voter or timestamp,
+// and the voter's participation in the same transaction
+func (r *RankedBallotPostgresRepository) CreateSecret(rankedBallot *ballot.RankedBallot, rankings []ballot.BallotRanking, participation *ballot.Participation) error {
+	return r.create(rankedBallot, rankings, participation, false)
+}
+
+// Replace stores a ranked ballot as the voter's current ballot in its election. The voter's earlier
+// accepted ballots are marked superseded and taken out of the pairwise tally in the same transaction.
+func (r *RankedBallotPostgresRepository) Replace(rankedBallot *ballot.RankedBallot, rankings []ballot.BallotRanking) error {
+	return r.create(rankedBallot, rankings, nil, true)
+}
+
+// create stores a ranked ballot with its rankings and, if participation is not nil, the voter's participation,
+// and marks the voter as having voted in one transaction. With supersede, the voter's earlier ballots in the
+// election are superseded by the new one. A second ballot that the election's unique constraints refuse is
+// returned as a *voter.AlreadyVotedError.
+func (r *RankedBallotPostgresRepository) create(rankedBallot *ballot.RankedBallot, rankings []ballot.BallotRanking, participation *ballot.Participation, supersede bool) error {
+	return r.uow.Do(func(tx *sql.Tx) error {
+		// Secret ballots carry no voter; the participation says whose ballot it is
+		voterID := rankedBallot.VoterID
+		if participation != nil {
+			voterID = participation.VoterID
+		}
+
+		// Record the voter's participation; its primary key lets a voter take part in an election once
+		if participation != nil {
+			participationQuery := `INSERT INTO election_participation (election_id, voter_id) VALUES ($1, $2)`
+
+			if _, err := tx.Exec(participationQuery, participation.ElectionID, participation.VoterID); err != nil {
+				if strings.Contains(err.Error(), "election_participation_pkey") {
+					return &voter.AlreadyVotedError{VoterID: participation.VoterID, ElectionID: participation.ElectionID}
+				}
+				return fmt.Errorf("failed to record participation: %v", err)
+			}
+		}
+
+		// Supersede the voter's current ballot
+		var superseded [][]ballot.BallotRanking
+		if supersede {
+			var err error
+			if superseded, err = supersedeRankedBallots(tx, rankedBallot.ElectionID, rankedBallot.VoterID); err != nil {
+				return err
+			}
+		}
+
+		// Insert ranked ballot; secret ballots store neither voter nor timestamp
+		ballotQuery := `
+			INSERT INTO ranked_ballots 
+			(ballot_id, election_id, voter_id, timestamp, status, tracking_code)
+			VALUES ($1, $2, NULLIF($3, 0), $4, $5, NULLIF($6, ''))`
+
+		_, err := tx.Exec(
+			ballotQuery,
+			rankedBallot.BallotID,
+			rankedBallot.ElectionID,
+			rankedBallot.VoterID,
+			sql.NullTime{Time: rankedBallot.Timestamp, Valid: !rankedBallot.Timestamp.IsZero()},
+			rankedBallot.Status,
+			rankedBallot.TrackingCode,
+		)
+		if err != nil {
+			// A taken ballot ID is retried by the caller with a new one, which also derives a new tracking code
+			if strings.Contains(err.Error(), "ranked_ballots_pkey") || strings.Contains(err.Error(), "ranked_ballots_tracking_code_key") {
+				return fmt.Errorf("%w: %s", ballot.ErrBallotIDConflict, rankedBallot.BallotID)
+			}
+			// A voter has one accepted ballot per election
+			if strings.Contains(err.Error(), "ranked_ballots_election_id_voter_id_key") {
+				return &voter.AlreadyVotedError{VoterID: rankedBallot.VoterID, ElectionID: rankedBallot.ElectionID}
+			}
+			return fmt.Errorf("failed to create ranked ballot: %v", err)
+		}
+
+		// Insert ballot rankings
+		if len(rankings) > 0 {
+			rankingQuery := `
+				INSERT INTO ballot_rankings 
+				(ballot_id, candidate_id, rank_position)
+				VALUES ($1, $2, $3)`
+
+			for _, ranking := range rankings {
+				_, err := tx.Exec(
+					rankingQuery,
+					ranking.BallotID,
+					ranking.CandidateID,
+					ranking.RankPosition,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create ballot ranking: %v", err)
+				}
+			}
+		}
+
+		// Add the ballot to the election's pairwise tally, and take out the ballots it supersedes,
+		// so the tally always matches the accepted ballots
+		if err := updatePairwiseTally(tx, rankedBallot.ElectionID, superseded, rankings); err != nil {
+			return err
+		}
+
+		// The voter is only marked as having voted if the ballot is stored
+		return markVoted(tx, voterID)
+	})
+}
+
+// GetPairwiseTally retrieves the stored pairwise tally of an election
+func (r *RankedBallotPostgresRepository) GetPairwiseTally(electionID string) (*ballot.PairwiseTally, error) {
+	candidateQuery := `
+		SELECT candidate_id, ranked
+		FROM candidate_tallies
+		WHERE election_id = $1
+		ORDER BY candidate_id ASC`
+
+	rows, err := r.db.Query(candidateQuery, electionID)
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %v", err)
+		return nil, fmt.Errorf("failed to query candidate tallies: %v", err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
+	defer rows.Close()
+
+	candidates := []ballot.CandidateTally{}
+	for rows.Next() {
+		var candidate ballot.CandidateTally
+		if err := rows.Scan(&candidate.CandidateID, &candidate.Ranked); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate tally: %v", err)
 		}
-	}()
+		candidates = append(candidates, candidate)
+	}
 
-	// Insert ranked ballot
-	ballotQuery := `
-		INSERT INTO ranked_ballots 
-		(ballot_id, election_id, voter_id, timestamp, status)
-		VALUES ($1, $2, $3, $4, $5)`
-
-	_, err = tx.Exec(
-		ballotQuery,
-		rankedBallot.BallotID,
-		rankedBallot.ElectionID,
-		rankedBallot.VoterID,
-		rankedBallot.Timestamp,
-		rankedBallot.Status,
-	)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidate tallies: %v", err)
+	}
+
+	pairQuery := `
+		SELECT candidate_a, candidate_b, a_over_b, b_over_a, equal
+		FROM pairwise_tallies
+		WHERE election_id = $1
+		ORDER BY candidate_a ASC, candidate_b ASC`
+
+	pairRows, err := r.db.Query(pairQuery, electionID)
 	if err != nil {
-		return fmt.Errorf("failed to create ranked ballot: %v", err)
-	}
-
-	// Insert ballot rankings
-	if len(rankings) > 0 {
-		rankingQuery := `
-			INSERT INTO ballot_rankings 
-			(ballot_id, candidate_id, rank_position)
-			VALUES ($1, $2, $3)`
-
-		for _, ranking := range rankings {
-			_, err = tx.Exec(
-				rankingQuery,
-				ranking.BallotID,
-				ranking.CandidateID,
-				ranking.RankPosition,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to create ballot ranking: %v", err)
-			}
+		return nil, fmt.Errorf("failed to query pairwise tallies: %v", err)
+	}
+	defer pairRows.Close()
+
+	pairs := []ballot.PairTally{}
+	for pairRows.Next() {
+		var pair ballot.PairTally
+		if err := pairRows.Scan(&pair.CandidateA, &pair.CandidateB, &pair.AOverB, &pair.BOverA, &pair.Equal); err != nil {
+			return nil, fmt.Errorf("failed to scan pairwise tally: %v", err)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	if err = pairRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pairwise tallies: %v", err)
+	}
+
+	return ballot.RestorePairwiseTally(candidates, pairs), nil
+}
+
+// supersedeRankedBallots marks a voter's accepted ballots in an election as superseded and returns
+// their rankings. The advisory lock serializes a voter's ballots in the election, so two concurrent
+// ballots cannot both stay accepted.
+func supersedeRankedBallots(tx *sql.Tx, electionID string, voterID int) ([][]ballot.BallotRanking, error) {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('ranked:' || $1 || ':' || $2::text))`, electionID, voterID); err != nil {
+		return nil, fmt.Errorf("failed to lock voter ballots: %v", err)
+	}
+
+	query := `
+		UPDATE ranked_ballots SET status = $3
+		WHERE election_id = $1 AND voter_id = $2 AND status = $4
+		RETURNING ballot_id`
+
+	rows, err := tx.Query(query, electionID, voterID, ballot.BallotStatusSuperseded, ballot.BallotStatusAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to supersede ranked ballots: %v", err)
+	}
+	var ballotIDs []string
+	for rows.Next() {
+		var ballotID string
+		if err := rows.Scan(&ballotID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan superseded ballot: %v", err)
+		}
+		ballotIDs = append(ballotIDs, ballotID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating superseded ballots: %v", err)
+	}
+	if len(ballotIDs) == 0 {
+		return nil, nil
+	}
+
+	rankingsQuery := `
+		SELECT ballot_id, candidate_id, rank_position
+		FROM ballot_rankings
+		WHERE ballot_id = ANY($1)
+		ORDER BY ballot_id, rank_position ASC, candidate_id ASC`
+
+	rankingRows, err := tx.Query(rankingsQuery, pq.Array(ballotIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query superseded rankings: %v", err)
+	}
+	defer rankingRows.Close()
+
+	byBallot := make(map[string][]ballot.BallotRanking, len(ballotIDs))
+	for rankingRows.Next() {
+		var ranking ballot.BallotRanking
+		if err := rankingRows.Scan(&ranking.BallotID, &ranking.CandidateID, &ranking.RankPosition); err != nil {
+			return nil, fmt.Errorf("failed to scan superseded ranking: %v", err)
+		}
+		byBallot[ranking.BallotID] = append(byBallot[ranking.BallotID], ranking)
+	}
+	if err := rankingRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating superseded rankings: %v", err)
+	}
+
+	superseded := make([][]ballot.BallotRanking, 0, len(ballotIDs))
+	for _, ballotID := range ballotIDs {
+		superseded = append(superseded, byBallot[ballotID])
+	}
+	return superseded, nil
+}
+
+// updatePairwiseTally adds one ballot's counts to its election's pairwise tally, less the counts of the
+// ballots it supersedes, with one upsert per table. Rows are upserted in candidate order so concurrent
+// ballots lock them in the same order; counts a superseded ballot brings to zero are deleted, as a
+// tally rebuilt from the accepted ballots would not have them.
+func updatePairwiseTally(tx *sql.Tx, electionID string, superseded [][]ballot.BallotRanking, rankings []ballot.BallotRanking) error {
+	candidates, pairs := ballot.ReplacementTally(superseded, rankings)
+
+	candidateIDs := make([]int64, len(candidates))
+	ranked := make([]int64, len(candidates))
+	for i, candidate := range candidates {
+		candidateIDs[i] = int64(candidate.CandidateID)
+		ranked[i] = int64(candidate.Ranked)
+	}
+
+	candidateQuery := `
+		INSERT INTO candidate_tallies (election_id, candidate_id, ranked)
+		SELECT $1, t.candidate_id, t.ranked
+		FROM unnest($2::int[], $3::int[]) AS t(candidate_id, ranked)
+		ORDER BY t.candidate_id
+		ON CONFLICT (election_id, candidate_id)
+		DO UPDATE SET ranked = candidate_tallies.ranked + EXCLUDED.ranked`
+
+	if _, err := tx.Exec(candidateQuery, electionID, pq.Array(candidateIDs), pq.Array(ranked)); err != nil {
+		return fmt.Errorf("failed to update candidate tallies: %v", err)
+	}
+
+	if len(pairs) > 0 {
+		if err := upsertPairTallies(tx, electionID, pairs); err != nil {
+			return err
 		}
 	}
 
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %v", err)
+	if len(superseded) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM candidate_tallies WHERE election_id = $1 AND ranked = 0`, electionID); err != nil {
+		return fmt.Errorf("failed to prune candidate tallies: %v", err)
+	}
+	pruneQuery := `DELETE FROM pairwise_tallies WHERE election_id = $1 AND a_over_b = 0 AND b_over_a = 0 AND equal = 0`
+	if _, err := tx.Exec(pruneQuery, electionID); err != nil {
+		return fmt.Errorf("failed to prune pairwise tallies: %v", err)
+	}
+
+	return nil
+}
+
+// upsertPairTallies adds pair counts to an election's pairwise tally
+func upsertPairTallies(tx *sql.Tx, electionID string, pairs []ballot.PairTally) error {
+
+	candidateA := make([]int64, len(pairs))
+	candidateB := make([]int64, len(pairs))
+	aOverB := make([]int64, len(pairs))
+	bOverA := make([]int64, len(pairs))
+	equal := make([]int64, len(pairs))
+	for i, pair := range pairs {
+		candidateA[i] = int64(pair.CandidateA)
+		candidateB[i] = int64(pair.CandidateB)
+		aOverB[i] = int64(pair.AOverB)
+		bOverA[i] = int64(pair.BOverA)
+		equal[i] = int64(pair.Equal)
+	}
+
+	pairQuery := `
+		INSERT INTO pairwise_tallies (election_id, candidate_a, candidate_b, a_over_b, b_over_a, equal)
+		SELECT $1, t.candidate_a, t.candidate_b, t.a_over_b, t.b_over_a, t.equal
+		FROM unnest($2::int[], $3::int[], $4::int[], $5::int[], $6::int[]) AS t(candidate_a, candidate_b, a_over_b, b_over_a, equal)
+		ORDER BY t.candidate_a, t.candidate_b
+		ON CONFLICT (election_id, candidate_a, candidate_b)
+		DO UPDATE SET a_over_b = pairwise_tallies.a_over_b + EXCLUDED.a_over_b,
+			b_over_a = pairwise_tallies.b_over_a + EXCLUDED.b_over_a,
+			equal = pairwise_tallies.equal + EXCLUDED.equal`
+
+	_, err := tx.Exec(pairQuery, electionID,
+		pq.Array(candidateA), pq.Array(candidateB), pq.Array(aOverB), pq.Array(bOverA), pq.Array(equal))
+	if err != nil {
+		return fmt.Errorf("failed to update pairwise tallies: %v", err)
 	}
 
 	return nil
@@ -80,19 +335,21 @@ func (r *RankedBallotPostgresRepository) Create(rankedBallot *ballot.RankedBallo
 func (r *RankedBallotPostgresRepository) GetByBallotID(ballotID string) (*ballot.RankedBallot, []ballot.BallotRanking, error) {
 	// Get the ballot
 	ballotQuery := `
-		SELECT ballot_id, election_id, voter_id, timestamp, status
+		SELECT ballot_id, election_id, COALESCE(voter_id, 0), timestamp, status, COALESCE(tracking_code, '')
 		FROM ranked_ballots
 		WHERE ballot_id = $1`
 
 	row := r.db.QueryRow(ballotQuery, ballotID)
 
 	var rankedBallot ballot.RankedBallot
+	var timestamp sql.NullTime
 	err := row.Scan(
 		&rankedBallot.BallotID,
 		&rankedBallot.ElectionID,
 		&rankedBallot.VoterID,
-		&rankedBallot.Timestamp,
+		&timestamp,
 		&rankedBallot.Status,
+		&rankedBallot.TrackingCode,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -100,13 +357,14 @@ func (r *RankedBallotPostgresRepository) GetByBallotID(ballotID string) (*ballot
 		}
 		return nil, nil, fmt.Errorf("failed to get ranked ballot: %v", err)
 	}
+	rankedBallot.Timestamp = timestamp.Time
 
 	// Get the rankings
 	rankingsQuery := `
 		SELECT id, ballot_id, candidate_id, rank_position
 		FROM ballot_rankings
 		WHERE ballot_id = $1
-		ORDER BY rank_position ASC`
+		ORDER BY rank_position ASC, candidate_id ASC`
 
 	rows, err := r.db.Query(rankingsQuery, ballotID)
 	if err != nil {
@@ -136,27 +394,51 @@ func (r *RankedBallotPostgresRepository) GetByBallotID(ballotID string) (*ballot
 	return &rankedBallot, rankings, nil
 }
 
-// GetByElectionID retrieves all ranked ballots with rankings for an election
+// GetByElectionID retrieves the counted ranked ballots with rankings for an election in ballot ID order
 func (r *RankedBallotPostgresRepository) GetByElectionID(electionID string) ([]ballot.RankedBallotWithRankings, error) {
+	var results []ballot.RankedBallotWithRankings
+	err := r.StreamByElectionID(electionID, func(rankedBallot ballot.RankedBallotWithRankings) error {
+		// The streamed rankings are reused for the next ballot
+		rankings := make([]ballot.BallotRanking, len(rankedBallot.Rankings))
+		copy(rankings, rankedBallot.Rankings)
+		results = append(results, ballot.RankedBallotWithRankings{
+			Ballot:   rankedBallot.Ballot,
+			Rankings: rankings,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// StreamByElectionID passes each counted ranked ballot of an election with its rankings to fn in ballot ID order;
+// superseded ballots are left out.
+// Rows are read as they arrive from Postgres, so only one ballot is held in memory at a time;
+// the rankings slice passed to fn is reused for the next ballot.
+func (r *RankedBallotPostgresRepository) StreamByElectionID(electionID string, fn func(ballot.RankedBallotWithRankings) error) error {
 	query := `
-		SELECT rb.ballot_id, rb.election_id, rb.voter_id, rb.timestamp, rb.status,
+		SELECT rb.ballot_id, rb.election_id, COALESCE(rb.voter_id, 0), rb.timestamp, rb.status, COALESCE(rb.tracking_code, ''),
 			   br.id, br.ballot_id, br.candidate_id, br.rank_position
 		FROM ranked_ballots rb
 		LEFT JOIN ballot_rankings br ON rb.ballot_id = br.ballot_id
-		WHERE rb.election_id = $1
-		ORDER BY rb.ballot_id, br.rank_position ASC`
+		WHERE rb.election_id = $1 AND rb.status = $2
+		ORDER BY rb.ballot_id, br.rank_position ASC, br.candidate_id ASC`
 
-	rows, err := r.db.Query(query, electionID)
+	rows, err := r.db.Query(query, electionID, ballot.BallotStatusAccepted)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query ranked ballots: %v", err)
+		return fmt.Errorf("failed to query ranked ballots: %v", err)
 	}
 	defer rows.Close()
 
-	ballotMap := make(map[string]*ballot.RankedBallotWithRankings)
+	var current ballot.RankedBallotWithRankings
+	started := false
 
 	for rows.Next() {
 		var ballotData ballot.RankedBallot
-		var rankingData ballot.BallotRanking
+		var timestamp sql.NullTime
 		var rankingID sql.NullInt32
 		var rankingBallotID sql.NullString
 		var candidateID sql.NullInt32
@@ -166,52 +448,56 @@ func (r *RankedBallotPostgresRepository) GetByElectionID(electionID string) ([]b
 			&ballotData.BallotID,
 			&ballotData.ElectionID,
 			&ballotData.VoterID,
-			&ballotData.Timestamp,
+			&timestamp,
 			&ballotData.Status,
+			&ballotData.TrackingCode,
 			&rankingID,
 			&rankingBallotID,
 			&candidateID,
 			&rankPosition,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan ranked ballot: %v", err)
+			return fmt.Errorf("failed to scan ranked ballot: %v", err)
 		}
-
-		// Check if ballot exists in map
-		if _, exists := ballotMap[ballotData.BallotID]; !exists {
-			ballotMap[ballotData.BallotID] = &ballot.RankedBallotWithRankings{
-				Ballot:   ballotData,
-				Rankings: []ballot.BallotRanking{},
+		ballotData.Timestamp = timestamp.Time
+
+		// Rows arrive grouped by ballot; pass on the previous ballot once the next one starts
+		if !started || current.Ballot.BallotID != ballotData.BallotID {
+			if started {
+				if err := fn(current); err != nil {
+					return err
+				}
 			}
+			current.Ballot = ballotData
+			current.Rankings = current.Rankings[:0]
+			started = true
 		}
 
 		// Add ranking if it exists (LEFT JOIN might return null rankings)
 		if rankingID.Valid {
-			rankingData.ID = int(rankingID.Int32)
-			rankingData.BallotID = rankingBallotID.String
-			rankingData.CandidateID = int(candidateID.Int32)
-			rankingData.RankPosition = int(rankPosition.Int32)
-			ballotMap[ballotData.BallotID].Rankings = append(ballotMap[ballotData.BallotID].Rankings, rankingData)
+			current.Rankings = append(current.Rankings, ballot.BallotRanking{
+				ID:           int(rankingID.Int32),
+				BallotID:     rankingBallotID.String,
+				CandidateID:  int(candidateID.Int32),
+				RankPosition: int(rankPosition.Int32),
+			})
 		}
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating ranked ballots: %v", err)
+		return fmt.Errorf("error iterating ranked ballots: %v", err)
 	}
 
-	// Convert map to slice
-	var results []ballot.RankedBallotWithRankings
-	for _, ballotWithRankings := range ballotMap {
-		results = append(results, *ballotWithRankings)
+	if started {
+		return fn(current)
 	}
-
-	return results, nil
+	return nil
 }
 
 // GetByVoterID retrieves all ranked ballots for a voter
 func (r *RankedBallotPostgresRepository) GetByVoterID(voterID int) ([]*ballot.RankedBallot, error) {
 	query := `
-		SELECT ballot_id, election_id, voter_id, timestamp, status
+		SELECT ballot_id, election_id, voter_id, timestamp, status, COALESCE(tracking_code, '')
 		FROM ranked_ballots
 		WHERE voter_id = $1
 		ORDER BY timestamp DESC`
@@ -231,6 +517,7 @@ func (r *RankedBallotPostgresRepository) GetByVoterID(voterID int) ([]*ballot.Ra
 			&rankedBallot.VoterID,
 			&rankedBallot.Timestamp,
 			&rankedBallot.Status,
+			&rankedBallot.TrackingCode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ranked ballot: %v", err)
@@ -244,3 +531,46 @@ func (r *RankedBallotPostgresRepository) GetByVoterID(voterID int) ([]*ballot.Ra
 
 	return ballots, nil
 }
+
+// HasParticipated reports whether a voter has taken part in a secret-ballot election
+func (r *RankedBallotPostgresRepository) HasParticipated(electionID string, voterID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM election_participation WHERE election_id = $1 AND voter_id = $2)`
+
+	var participated bool
+	if err := r.db.QueryRow(query, electionID, voterID).Scan(&participated); err != nil {
+		return false, fmt.Errorf("failed to check participation: %v", err)
+	}
+
+	return participated, nil
+}
+
+// GetParticipation retrieves the ranked elections a voter cast a ballot in, whether their
+// ballots are stored with the voter or apart from it
+func (r *RankedBallotPostgresRepository) GetParticipation(voterID int) ([]*ballot.Participation, error) {
+	query := `
+		SELECT election_id, voter_id FROM election_participation WHERE voter_id = $1
+		UNION
+		SELECT election_id, voter_id FROM ranked_ballots WHERE voter_id = $1
+		ORDER BY election_id`
+
+	rows, err := r.db.Query(query, voterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query participation: %v", err)
+	}
+	defer rows.Close()
+
+	participation := []*ballot.Participation{}
+	for rows.Next() {
+		var p ballot.Participation
+		if err := rows.Scan(&p.ElectionID, &p.VoterID); err != nil {
+			return nil, fmt.Errorf("failed to scan participation: %v", err)
+		}
+		participation = append(participation, &p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating participation: %v", err)
+	}
+
+	return participation, nil
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/receipt"
+)
+
+// PostgresReceiptRepository implements the receipt.Repository interface
+type PostgresReceiptRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReceiptRepository creates a new PostgreSQL receipt repository
+func NewPostgresReceiptRepository(db *sql.DB) receipt.Repository {
+	return &PostgresReceiptRepository{db: db}
+}
+
+// GetByTrackingCode retrieves the receipt of the encrypted or ranked ballot with a tracking code,
+// or nil if no ballot has it
+func (r *PostgresReceiptRepository) GetByTrackingCode(code string) (*receipt.Receipt, error) {
+	encrypted, err := r.getEncryptedReceipt(code)
+	if err != nil || encrypted != nil {
+		return encrypted, err
+	}
+	return r.getRankedReceipt(code)
+}
+
+// getEncryptedReceipt locates an encrypted ballot on its election's bulletin board
+func (r *PostgresReceiptRepository) getEncryptedReceipt(code string) (*receipt.Receipt, error) {
+	query := `
+		SELECT eb.election_id, eb.status, be.sequence, be.ballot_hash, be.hash,
+			   (SELECT MIN(bc.size) FROM board_checkpoints bc
+			    WHERE bc.election_id = be.election_id AND bc.size >= be.sequence)
+		FROM encrypted_ballots eb
+		LEFT JOIN board_entries be ON be.ballot_id = eb.ballot_id
+		WHERE eb.tracking_code = $1`
+
+	rec := receipt.Receipt{TrackingCode: code, BallotType: receipt.BallotTypeEncrypted}
+	var sequence, checkpointSize sql.NullInt64
+	var ballotHash, entryHash sql.NullString
+	err := r.db.QueryRow(query, code).Scan(&rec.ElectionID, &rec.Status, &sequence, &ballotHash, &entryHash, &checkpointSize)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get encrypted ballot receipt: %v", err)
+	}
+
+	rec.Included = rec.Status == ballot.BallotStatusAccepted && sequence.Valid
+	if sequence.Valid {
+		rec.BoardSequence = &sequence.Int64
+		rec.BallotHash = ballotHash.String
+		rec.EntryHash = entryHash.String
+	}
+	if checkpointSize.Valid {
+		rec.CheckpointSize = &checkpointSize.Int64
+	}
+
+	return &rec, nil
+}
+
+// getRankedReceipt locates a ranked ballot among the ballots its election counts
+func (r *PostgresReceiptRepository) getRankedReceipt(code string) (*receipt.Receipt, error) {
+	query := `SELECT election_id, status FROM ranked_ballots WHERE tracking_code = $1`
+
+	rec := receipt.Receipt{TrackingCode: code, BallotType: receipt.BallotTypeRanked}
+	err := r.db.QueryRow(query, code).Scan(&rec.ElectionID, &rec.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ranked ballot receipt: %v", err)
+	}
+
+	rec.Included = rec.Status == ballot.BallotStatusAccepted
+	return &rec, nil
+}
@@ -0,0 +1,398 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/trustee"
+	"github.com/lib/pq"
+)
+
+// PostgresTrusteeRepository implements the trustee.Repository interface
+type PostgresTrusteeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTrusteeRepository creates a new PostgreSQL trustee repository
+func NewPostgresTrusteeRepository(db *sql.DB) trustee.Repository {
+	return &PostgresTrusteeRepository{db: db}
+}
+
+// CreateCeremony stores a new key generation ceremony with its trustees
+func (r *PostgresTrusteeRepository) CreateCeremony(ceremony *trustee.Ceremony) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	ceremony.CreatedAt = time.Now()
+	_, err = tx.Exec(`
+		INSERT INTO trustee_ceremonies (election_id, threshold, created_at)
+		VALUES ($1, $2, $3)`,
+		ceremony.ElectionID, ceremony.Threshold, ceremony.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "trustee_ceremonies_pkey") {
+			err = fmt.Errorf("election %s already has a trustee ceremony", ceremony.ElectionID)
+			return err
+		}
+		return fmt.Errorf("failed to create trustee ceremony: %w", err)
+	}
+
+	for _, t := range ceremony.Trustees {
+		t.ElectionID = ceremony.ElectionID
+		_, err = tx.Exec(`
+			INSERT INTO trustees (election_id, trustee_index, name)
+			VALUES ($1, $2, $3)`,
+			t.ElectionID, t.Index, t.Name)
+		if err != nil {
+			return fmt.Errorf("failed to create trustee: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetCeremony retrieves the key generation ceremony of an election with its trustees and complaints,
+// or nil if the election has none
+func (r *PostgresTrusteeRepository) GetCeremony(electionID string) (*trustee.Ceremony, error) {
+	ceremony := &trustee.Ceremony{ElectionID: electionID}
+	var finalizedAt sql.NullTime
+
+	err := r.db.QueryRow(`
+		SELECT threshold, created_at, finalized_at
+		FROM trustee_ceremonies
+		WHERE election_id = $1`, electionID).Scan(&ceremony.Threshold, &ceremony.CreatedAt, &finalizedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get trustee ceremony: %w", err)
+	}
+	if finalizedAt.Valid {
+		ceremony.FinalizedAt = &finalizedAt.Time
+	}
+
+	if ceremony.Trustees, err = r.getTrustees(electionID); err != nil {
+		return nil, err
+	}
+	if ceremony.Complaints, err = r.getComplaints(electionID); err != nil {
+		return nil, err
+	}
+
+	return ceremony, nil
+}
+
+// SaveCommitments stores a trustee's commitments and proof. A trustee commits once, before the ceremony is finalized.
+func (r *PostgresTrusteeRepository) SaveCommitments(t *trustee.Trustee) error {
+	committedAt := time.Now()
+
+	proof, err := json.Marshal(t.CommitmentProof)
+	if err != nil {
+		return fmt.Errorf("failed to encode commitment proof: %w", err)
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE trustees
+		SET commitments = $3, commitment_proof = $4, committed_at = $5
+		WHERE election_id = $1 AND trustee_index = $2 AND committed_at IS NULL
+		AND EXISTS (SELECT 1 FROM trustee_ceremonies WHERE election_id = $1 AND finalized_at IS NULL)`,
+		t.ElectionID, t.Index, pq.Array(t.Commitments), string(proof), committedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save commitments: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("trustee %d of election %s has already committed or the ceremony is already finalized", t.Index, t.ElectionID)
+	}
+
+	t.CommittedAt = &committedAt
+	return nil
+}
+
+// CreateComplaint stores a complaint against a dealer while the ceremony is not finalized
+func (r *PostgresTrusteeRepository) CreateComplaint(complaint *trustee.Complaint) error {
+	complaint.CreatedAt = time.Now()
+
+	result, err := r.db.Exec(`
+		INSERT INTO trustee_complaints (election_id, dealer_index, complainer_index, created_at)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (SELECT 1 FROM trustee_ceremonies WHERE election_id = $1 AND finalized_at IS NULL)`,
+		complaint.ElectionID, complaint.Dealer, complaint.Complainer, complaint.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "trustee_complaints_pkey") {
+			return fmt.Errorf("trustee %d already complained about trustee %d", complaint.Complainer, complaint.Dealer)
+		}
+		return fmt.Errorf("failed to create complaint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("key generation for election %s is already finalized", complaint.ElectionID)
+	}
+
+	return nil
+}
+
+// ResolveComplaint records the share a dealer revealed to answer a complaint,
+// disqualifying the dealer if the share was invalid
+func (r *PostgresTrusteeRepository) ResolveComplaint(complaint *trustee.Complaint, disqualify bool) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	resolvedAt := time.Now()
+	result, err := tx.Exec(`
+		UPDATE trustee_complaints
+		SET revealed_share = $4, resolved_at = $5
+		WHERE election_id = $1 AND dealer_index = $2 AND complainer_index = $3 AND resolved_at IS NULL
+		AND EXISTS (SELECT 1 FROM trustee_ceremonies WHERE election_id = $1 AND finalized_at IS NULL)`,
+		complaint.ElectionID, complaint.Dealer, complaint.Complainer, complaint.RevealedShare, resolvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to resolve complaint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		err = fmt.Errorf("complaint of trustee %d about trustee %d is already resolved or the ceremony is already finalized", complaint.Complainer, complaint.Dealer)
+		return err
+	}
+
+	if disqualify {
+		_, err = tx.Exec(`
+			UPDATE trustees SET disqualified = true
+			WHERE election_id = $1 AND trustee_index = $2`,
+			complaint.ElectionID, complaint.Dealer)
+		if err != nil {
+			return fmt.Errorf("failed to disqualify trustee: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	complaint.ResolvedAt = &resolvedAt
+	return nil
+}
+
+// Finalize stores the outcome of a ceremony: the trustees' disqualification and verification keys
+// and the election key. A ceremony is only finalized once.
+func (r *PostgresTrusteeRepository) Finalize(ceremony *trustee.Ceremony, key *ballot.ElectionKey) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	finalizedAt := time.Now()
+	result, err := tx.Exec(`
+		UPDATE trustee_ceremonies SET finalized_at = $2
+		WHERE election_id = $1 AND finalized_at IS NULL`,
+		ceremony.ElectionID, finalizedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finalize trustee ceremony: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		err = fmt.Errorf("key generation for election %s is already finalized", ceremony.ElectionID)
+		return err
+	}
+
+	for _, t := range ceremony.Trustees {
+		_, err = tx.Exec(`
+			UPDATE trustees SET disqualified = $3, verification_key = $4
+			WHERE election_id = $1 AND trustee_index = $2`,
+			ceremony.ElectionID, t.Index, t.Disqualified, t.VerificationKey)
+		if err != nil {
+			return fmt.Errorf("failed to update trustee: %w", err)
+		}
+	}
+
+	key.CreatedAt = finalizedAt
+	_, err = tx.Exec(`
+		INSERT INTO election_keys (election_id, public_key, threshold, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		key.ElectionID, key.PublicKey, key.Threshold, key.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "election_keys_pkey") {
+			err = fmt.Errorf("election %s already has a key", key.ElectionID)
+			return err
+		}
+		return fmt.Errorf("failed to create election key: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	ceremony.FinalizedAt = &finalizedAt
+	return nil
+}
+
+// CreateDecryptionShare stores a trustee's decryption share. A trustee submits one share per election.
+func (r *PostgresTrusteeRepository) CreateDecryptionShare(share *trustee.DecryptionShare) error {
+	proofs, err := json.Marshal(share.Proofs)
+	if err != nil {
+		return fmt.Errorf("failed to encode proofs: %w", err)
+	}
+
+	share.SubmittedAt = time.Now()
+	_, err = r.db.Exec(`
+		INSERT INTO decryption_shares (election_id, trustee_index, factors, proofs, submitted_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		share.ElectionID, share.TrusteeIndex, pq.Array(share.Factors), string(proofs), share.SubmittedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "decryption_shares_pkey") {
+			return fmt.Errorf("trustee %d already submitted a decryption share for election %s", share.TrusteeIndex, share.ElectionID)
+		}
+		return fmt.Errorf("failed to create decryption share: %w", err)
+	}
+
+	return nil
+}
+
+// GetDecryptionShares retrieves the decryption shares of an election in trustee order
+func (r *PostgresTrusteeRepository) GetDecryptionShares(electionID string) ([]*trustee.DecryptionShare, error) {
+	rows, err := r.db.Query(`
+		SELECT election_id, trustee_index, factors, proofs, submitted_at
+		FROM decryption_shares
+		WHERE election_id = $1
+		ORDER BY trustee_index`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decryption shares: %w", err)
+	}
+	defer rows.Close()
+
+	shares := []*trustee.DecryptionShare{}
+	for rows.Next() {
+		share := &trustee.DecryptionShare{}
+		var proofs []byte
+		err := rows.Scan(&share.ElectionID, &share.TrusteeIndex, pq.Array(&share.Factors), &proofs, &share.SubmittedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan decryption share: %w", err)
+		}
+		if err := json.Unmarshal(proofs, &share.Proofs); err != nil {
+			return nil, fmt.Errorf("failed to decode proofs: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decryption shares: %w", err)
+	}
+
+	return shares, nil
+}
+
+// getTrustees retrieves the trustees of a ceremony in index order
+func (r *PostgresTrusteeRepository) getTrustees(electionID string) ([]*trustee.Trustee, error) {
+	rows, err := r.db.Query(`
+		SELECT election_id, trustee_index, name, commitments, commitment_proof, committed_at, disqualified, verification_key
+		FROM trustees
+		WHERE election_id = $1
+		ORDER BY trustee_index`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trustees: %w", err)
+	}
+	defer rows.Close()
+
+	trustees := []*trustee.Trustee{}
+	for rows.Next() {
+		t := &trustee.Trustee{}
+		var proof []byte
+		var committedAt sql.NullTime
+		err := rows.Scan(&t.ElectionID, &t.Index, &t.Name, pq.Array(&t.Commitments), &proof, &committedAt, &t.Disqualified, &t.VerificationKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trustee: %w", err)
+		}
+		if proof != nil {
+			if err := json.Unmarshal(proof, &t.CommitmentProof); err != nil {
+				return nil, fmt.Errorf("failed to decode commitment proof: %w", err)
+			}
+		}
+		if committedAt.Valid {
+			t.CommittedAt = &committedAt.Time
+		}
+		trustees = append(trustees, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trustees: %w", err)
+	}
+
+	return trustees, nil
+}
+
+// getComplaints retrieves the complaints of a ceremony in the order they were made
+func (r *PostgresTrusteeRepository) getComplaints(electionID string) ([]*trustee.Complaint, error) {
+	rows, err := r.db.Query(`
+		SELECT election_id, dealer_index, complainer_index, revealed_share, created_at, resolved_at
+		FROM trustee_complaints
+		WHERE election_id = $1
+		ORDER BY created_at, dealer_index, complainer_index`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get complaints: %w", err)
+	}
+	defer rows.Close()
+
+	complaints := []*trustee.Complaint{}
+	for rows.Next() {
+		c := &trustee.Complaint{}
+		var resolvedAt sql.NullTime
+		err := rows.Scan(&c.ElectionID, &c.Dealer, &c.Complainer, &c.RevealedShare, &c.CreatedAt, &resolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan complaint: %w", err)
+		}
+		if resolvedAt.Valid {
+			c.ResolvedAt = &resolvedAt.Time
+		}
+		complaints = append(complaints, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating complaints: %w", err)
+	}
+
+	return complaints, nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// UnitOfWork groups the writes of one operation, such as storing a ballot and marking its voter
+// as having voted, into a single transaction that is committed or rolled back as a whole
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+// NewUnitOfWork creates a unit of work over a database
+func NewUnitOfWork(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do runs fn in a new transaction, which is committed if fn returns nil and rolled back otherwise.
+// fn's error is returned unchanged, so a constraint violation fn maps to a domain error stays typed.
+func (u *UnitOfWork) Do(fn func(tx *sql.Tx) error) (err error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return nil
+}
@@ -3,19 +3,22 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/vote"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 	_ "github.com/lib/pq"
 )
 
 // PostgresVoteRepository implements the vote.Repository interface
 type PostgresVoteRepository struct {
-	db *sql.DB
+	db  *sql.DB
+	uow *UnitOfWork
 }
 
 // NewPostgresVoteRepository creates a new PostgreSQL vote repository
 func NewPostgresVoteRepository(db *sql.DB) vote.Repository {
-	return &PostgresVoteRepository{db: db}
+	return &PostgresVoteRepository{db: db, uow: NewUnitOfWork(db)}
 }
 
 // GetTimelineByCandidateID retrieves all votes for a specific candidate ordered by timestamp
@@ -50,7 +53,8 @@ func (r *PostgresVoteRepository) GetTimelineByCandidateID(candidateID int) ([]*v
 	return votes, nil
 }
 
-// CreateWeightedVote inserts a new weighted vote into the database
+// CreateWeightedVote inserts a new weighted vote and marks the voter as having voted in one transaction.
+// A second vote from the voter is returned as a *voter.AlreadyVotedError.
 func (r *PostgresVoteRepository) CreateWeightedVote(v *vote.Vote) error {
 	query := `
 		INSERT INTO votes (voter_id, candidate_id, weight, created_at, updated_at)
@@ -58,12 +62,17 @@ func (r *PostgresVoteRepository) CreateWeightedVote(v *vote.Vote) error {
 		RETURNING vote_id
 	`
 
-	err := r.db.QueryRow(query, v.VoterID, v.CandidateID, v.Weight, v.CreatedAt, v.UpdatedAt).Scan(&v.VoteID)
-	if err != nil {
-		return fmt.Errorf("failed to create weighted vote: %w", err)
-	}
+	return r.uow.Do(func(tx *sql.Tx) error {
+		err := tx.QueryRow(query, v.VoterID, v.CandidateID, v.Weight, v.CreatedAt, v.UpdatedAt).Scan(&v.VoteID)
+		if err != nil {
+			if strings.Contains(err.Error(), "votes_voter_id_key") {
+				return &voter.AlreadyVotedError{VoterID: v.VoterID}
+			}
+			return fmt.Errorf("failed to create weighted vote: %w", err)
+		}
 
-	return nil
+		return markVoted(tx, v.VoterID)
+	})
 }
 
 // GetByID retrieves a vote by its ID
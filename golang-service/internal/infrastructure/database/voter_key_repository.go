@@ -0,0 +1,151 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+)
+
+// PostgresVoterKeyRepository implements the voter.KeyRepository interface
+type PostgresVoterKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresVoterKeyRepository creates a new PostgreSQL voter key repository
+func NewPostgresVoterKeyRepository(db *sql.DB) voter.KeyRepository {
+	return &PostgresVoterKeyRepository{db: db}
+}
+
+const voterKeyColumns = `key_id, voter_id, election_id, public_key, enrolled_at, revoked_at`
+
+// Create enrolls a new voter key, assigning its ID
+func (r *PostgresVoterKeyRepository) Create(key *voter.Key) error {
+	query := `
+		INSERT INTO voter_keys (voter_id, election_id, public_key, enrolled_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING key_id
+	`
+
+	key.EnrolledAt = time.Now()
+	key.RevokedAt = nil
+
+	err := r.db.QueryRow(query, key.VoterID, key.ElectionID, key.PublicKey, key.EnrolledAt).Scan(&key.KeyID)
+	if err != nil {
+		// A public key is enrolled at most once per election, even after it is revoked
+		if strings.Contains(err.Error(), "voter_keys_election_id_public_key_key") {
+			return fmt.Errorf("public_key is already enrolled in election %s", key.ElectionID)
+		}
+		return fmt.Errorf("failed to enroll voter key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a voter key by its ID
+func (r *PostgresVoterKeyRepository) GetByID(keyID int) (*voter.Key, error) {
+	query := `SELECT ` + voterKeyColumns + ` FROM voter_keys WHERE key_id = $1`
+
+	key, err := scanVoterKey(r.db.QueryRow(query, keyID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("voter key with id: %d was not found", keyID)
+		}
+		return nil, fmt.Errorf("failed to get voter key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetByVoterID retrieves the keys a voter enrolled, in enrollment order.
+// An empty election ID returns the voter's keys in every election.
+func (r *PostgresVoterKeyRepository) GetByVoterID(voterID int, electionID string) ([]*voter.Key, error) {
+	query := `
+		SELECT ` + voterKeyColumns + `
+		FROM voter_keys
+		WHERE voter_id = $1 AND ($2 = '' OR election_id = $2)
+		ORDER BY key_id
+	`
+
+	rows, err := r.db.Query(query, voterID, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voter keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*voter.Key{}
+	for rows.Next() {
+		key, err := scanVoterKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan voter key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating voter keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetActiveByPublicKey retrieves the unrevoked key enrolled with a public key in an election,
+// or nil if there is none
+func (r *PostgresVoterKeyRepository) GetActiveByPublicKey(electionID, publicKey string) (*voter.Key, error) {
+	query := `
+		SELECT ` + voterKeyColumns + `
+		FROM voter_keys
+		WHERE election_id = $1 AND public_key = $2 AND revoked_at IS NULL
+	`
+
+	key, err := scanVoterKey(r.db.QueryRow(query, electionID, publicKey))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get voter key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Revoke marks a voter key as revoked. A key is only revoked once.
+func (r *PostgresVoterKeyRepository) Revoke(key *voter.Key, revokedAt time.Time) error {
+	query := `UPDATE voter_keys SET revoked_at = $2 WHERE key_id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(query, key.KeyID, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke voter key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("voter key with id: %d is already revoked", key.KeyID)
+	}
+
+	key.RevokedAt = &revokedAt
+	return nil
+}
+
+// scanVoterKey scans a voter key from a row of voterKeyColumns
+func scanVoterKey(row rowScanner) (*voter.Key, error) {
+	key := &voter.Key{}
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&key.KeyID, &key.VoterID, &key.ElectionID, &key.PublicKey, &key.EnrolledAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return key, nil
+}
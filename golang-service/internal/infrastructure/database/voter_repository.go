@@ -119,6 +119,26 @@ func (r *PostgresVoterRepository) Update(v *voter.Voter) error {
 	return nil
 }
 
+// markVoted records that a voter has voted, in the transaction that stores the vote or ballot.
+// Unlike Update it leaves updated_at alone, which weights the voter's weighted vote.
+func markVoted(tx *sql.Tx, voterID int) error {
+	result, err := tx.Exec(`UPDATE voter SET has_voted = true WHERE voter_id = $1`, voterID)
+	if err != nil {
+		return fmt.Errorf("failed to update voter has_voted status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("voter with id: %d was not found", voterID)
+	}
+
+	return nil
+}
+
 // Delete removes a voter from the database
 func (r *PostgresVoterRepository) Delete(voterID int) error {
 	query := `DELETE FROM voter WHERE voter_id = $1`
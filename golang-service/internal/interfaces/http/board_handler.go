@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// BoardHandler handles HTTP requests for the public bulletin board of encrypted ballots
+type BoardHandler struct {
+	service *application.BoardService
+}
+
+// NewBoardHandler creates a new bulletin board handler
+func NewBoardHandler(service *application.BoardService) *BoardHandler {
+	return &BoardHandler{service: service}
+}
+
+// GetEntries handles GET /api/elections/{election_id}/board/entries?after={sequence}&limit={limit}
+func (h *BoardHandler) GetEntries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var after int64
+	if value := query.Get("after"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid after")
+			return
+		}
+		after = parsed
+	}
+
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	response, err := h.service.GetEntries(mux.Vars(r)["election_id"], after, limit)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetCheckpoints handles GET /api/elections/{election_id}/board/checkpoints
+func (h *BoardHandler) GetCheckpoints(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetCheckpoints(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateCheckpoint handles POST /api/elections/{election_id}/board/checkpoints
+func (h *BoardHandler) CreateCheckpoint(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.CreateCheckpoint(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetConsistencyProof handles GET /api/elections/{election_id}/board/consistency?from={size}&to={size}
+func (h *BoardHandler) GetConsistencyProof(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, err := strconv.ParseInt(query.Get("from"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid from")
+		return
+	}
+	to, err := strconv.ParseInt(query.Get("to"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid to")
+		return
+	}
+
+	response, err := h.service.GetConsistencyProof(mux.Vars(r)["election_id"], from, to)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeServiceError maps a service error to an HTTP status code.
+// A board that no longer matches its checkpoints is reported as a conflict.
+func (h *BoardHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case contains(err.Error(), "inconsistent"):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case containsDuplicateError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *BoardHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *BoardHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
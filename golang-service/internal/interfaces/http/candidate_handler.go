@@ -0,0 +1,136 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/candidate"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// CandidateHandler handles HTTP requests for candidate operations
+type CandidateHandler struct {
+	service candidate.Service
+}
+
+// NewCandidateHandler creates a new candidate HTTP handler
+func NewCandidateHandler(service candidate.Service) *CandidateHandler {
+	return &CandidateHandler{service: service}
+}
+
+// CreateCandidate handles POST /api/candidates
+func (h *CandidateHandler) CreateCandidate(w http.ResponseWriter, r *http.Request) {
+	var req candidate.CandidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.CreateCandidate(req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetCandidate handles GET /api/candidates/{candidate_id}
+func (h *CandidateHandler) GetCandidate(w http.ResponseWriter, r *http.Request) {
+	candidateID, err := strconv.Atoi(mux.Vars(r)["candidate_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid candidate ID")
+		return
+	}
+
+	response, err := h.service.GetCandidate(candidateID)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetAllCandidates handles GET /api/candidates?party={party}
+func (h *CandidateHandler) GetAllCandidates(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetAllCandidates(r.URL.Query().Get("party"))
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateCandidate handles PUT /api/candidates/{candidate_id}
+func (h *CandidateHandler) UpdateCandidate(w http.ResponseWriter, r *http.Request) {
+	candidateID, err := strconv.Atoi(mux.Vars(r)["candidate_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid candidate ID")
+		return
+	}
+
+	var req candidate.CandidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.UpdateCandidate(candidateID, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteCandidate handles DELETE /api/candidates/{candidate_id}
+func (h *CandidateHandler) DeleteCandidate(w http.ResponseWriter, r *http.Request) {
+	candidateID, err := strconv.Atoi(mux.Vars(r)["candidate_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid candidate ID")
+		return
+	}
+
+	if err := h.service.DeleteCandidate(candidateID); err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	successResponse := map[string]string{
+		"message": fmt.Sprintf("candidate with id: %d deleted successfully", candidateID),
+	}
+	h.writeJSONResponse(w, http.StatusOK, successResponse)
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *CandidateHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case contains(err.Error(), "referenced by"):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *CandidateHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *CandidateHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/gorilla/mux"
+)
+
+// CardinalBallotHandler handles HTTP requests for approval and score ballots
+type CardinalBallotHandler struct {
+	service *application.CardinalBallotService
+}
+
+// NewCardinalBallotHandler creates a new approval and score ballot handler
+func NewCardinalBallotHandler(service *application.CardinalBallotService) *CardinalBallotHandler {
+	return &CardinalBallotHandler{service: service}
+}
+
+// CreateApprovalBallot handles POST /api/ballots/approval
+func (h *CardinalBallotHandler) CreateApprovalBallot(w http.ResponseWriter, r *http.Request) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	// Parse request body
+	var req ballot.ApprovalBallotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "invalid JSON format",
+		})
+		return
+	}
+
+	// Create approval ballot
+	response, err := h.service.CreateApprovalBallot(&req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	// Return success response
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateScoreBallot handles POST /api/ballots/score
+func (h *CardinalBallotHandler) CreateScoreBallot(w http.ResponseWriter, r *http.Request) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	// Parse request body
+	var req ballot.ScoreBallotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "invalid JSON format",
+		})
+		return
+	}
+
+	// Create score ballot
+	response, err := h.service.CreateScoreBallot(&req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	// Return success response
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCardinalBallot handles GET /api/ballots/approval/{ballot_id} and GET /api/ballots/score/{ballot_id}
+func (h *CardinalBallotHandler) GetCardinalBallot(w http.ResponseWriter, r *http.Request) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	ballotID := mux.Vars(r)["ballot_id"]
+
+	// Get cardinal ballot
+	cardinalBallot, scores, err := h.service.GetCardinalBallot(ballotID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	// Combine ballot and scores for response
+	response := map[string]interface{}{
+		"ballot": cardinalBallot,
+		"scores": scores,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetApprovalBallotsByElection handles GET /api/ballots/approval?election_id={id}
+func (h *CardinalBallotHandler) GetApprovalBallotsByElection(w http.ResponseWriter, r *http.Request) {
+	h.getBallotsByElection(w, r, ballot.CardinalTypeApproval)
+}
+
+// GetScoreBallotsByElection handles GET /api/ballots/score?election_id={id}
+func (h *CardinalBallotHandler) GetScoreBallotsByElection(w http.ResponseWriter, r *http.Request) {
+	h.getBallotsByElection(w, r, ballot.CardinalTypeScore)
+}
+
+// GetApprovalVoterBallots handles GET /api/ballots/approval/voter/{voter_id}
+func (h *CardinalBallotHandler) GetApprovalVoterBallots(w http.ResponseWriter, r *http.Request) {
+	h.getVoterBallots(w, r, ballot.CardinalTypeApproval)
+}
+
+// GetScoreVoterBallots handles GET /api/ballots/score/voter/{voter_id}
+func (h *CardinalBallotHandler) GetScoreVoterBallots(w http.ResponseWriter, r *http.Request) {
+	h.getVoterBallots(w, r, ballot.CardinalTypeScore)
+}
+
+// getBallotsByElection writes every ballot of a type cast in the election given by the election_id query parameter
+func (h *CardinalBallotHandler) getBallotsByElection(w http.ResponseWriter, r *http.Request, ballotType string) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract election ID from query parameters
+	electionID := r.URL.Query().Get("election_id")
+	if electionID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "election_id query parameter is required",
+		})
+		return
+	}
+
+	ballots, err := h.service.GetCardinalBallotsByElection(electionID, ballotType)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"election_id": electionID,
+		"ballots":     ballots,
+		"count":       len(ballots),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getVoterBallots writes every ballot of a type cast by the voter in the path
+func (h *CardinalBallotHandler) getVoterBallots(w http.ResponseWriter, r *http.Request, ballotType string) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	voterID := parseIntFromString(mux.Vars(r)["voter_id"])
+	if voterID <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "voter_id must be a positive integer",
+		})
+		return
+	}
+
+	ballots, err := h.service.GetVoterBallots(voterID, ballotType)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"voter_id": voterID,
+		"ballots":  ballots,
+		"count":    len(ballots),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeError maps a service error to an HTTP status code
+func (h *CardinalBallotHandler) writeError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	if isAlreadyVoted(err) || containsPhaseError(err.Error()) {
+		statusCode = http.StatusConflict
+	} else if containsValidationError(err.Error()) {
+		statusCode = http.StatusBadRequest
+	} else if containsDuplicateError(err.Error()) {
+		statusCode = http.StatusConflict
+	} else if containsNotFoundError(err.Error()) {
+		statusCode = http.StatusNotFound
+	}
+
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/election"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// ElectionHandler handles HTTP requests for election operations
+type ElectionHandler struct {
+	service election.Service
+}
+
+// NewElectionHandler creates a new election HTTP handler
+func NewElectionHandler(service election.Service) *ElectionHandler {
+	return &ElectionHandler{service: service}
+}
+
+// CreateElection handles POST /api/elections
+func (h *ElectionHandler) CreateElection(w http.ResponseWriter, r *http.Request) {
+	var req election.ElectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.CreateElection(req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetElection handles GET /api/elections/{election_id}
+func (h *ElectionHandler) GetElection(w http.ResponseWriter, r *http.Request) {
+	electionID := mux.Vars(r)["election_id"]
+
+	response, err := h.service.GetElection(electionID)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetAllElections handles GET /api/elections
+func (h *ElectionHandler) GetAllElections(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetAllElections()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateElection handles PUT /api/elections/{election_id}
+func (h *ElectionHandler) UpdateElection(w http.ResponseWriter, r *http.Request) {
+	electionID := mux.Vars(r)["election_id"]
+
+	var req election.ElectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.UpdateElection(electionID, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteElection handles DELETE /api/elections/{election_id}
+func (h *ElectionHandler) DeleteElection(w http.ResponseWriter, r *http.Request) {
+	electionID := mux.Vars(r)["election_id"]
+
+	if err := h.service.DeleteElection(electionID); err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	successResponse := map[string]string{
+		"message": fmt.Sprintf("election with id: %s deleted successfully", electionID),
+	}
+	h.writeJSONResponse(w, http.StatusOK, successResponse)
+}
+
+// TransitionElection handles POST /api/elections/{election_id}/transitions
+func (h *ElectionHandler) TransitionElection(w http.ResponseWriter, r *http.Request) {
+	electionID := mux.Vars(r)["election_id"]
+
+	var req election.TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.TransitionElection(electionID, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetElectionTransitions handles GET /api/elections/{election_id}/transitions
+func (h *ElectionHandler) GetElectionTransitions(w http.ResponseWriter, r *http.Request) {
+	electionID := mux.Vars(r)["election_id"]
+
+	transitions, err := h.service.GetElectionTransitions(electionID)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, election.TransitionsResponse{
+		ElectionID:  electionID,
+		Transitions: transitions,
+	})
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *ElectionHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsPhaseError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case containsDuplicateError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *ElectionHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *ElectionHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
@@ -2,10 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/Nezent/Saracen_Voting_System/internal/application"
 	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
 )
 
 // EncryptedBallotHandler handles HTTP requests for encrypted ballots (Q16)
@@ -44,7 +46,9 @@ func (h *EncryptedBallotHandler) CreateEncryptedBallot(w http.ResponseWriter, r
 	if err != nil {
 		// Determine appropriate HTTP status code
 		statusCode := http.StatusInternalServerError
-		if containsValidationError(err.Error()) {
+		if isAlreadyVoted(err) || containsPhaseError(err.Error()) {
+			statusCode = http.StatusConflict
+		} else if containsValidationError(err.Error()) {
 			statusCode = http.StatusBadRequest
 		} else if containsDuplicateError(err.Error()) {
 			statusCode = http.StatusConflict
@@ -171,6 +175,16 @@ func containsDuplicateError(errMsg string) bool {
 	return contains(errMsg, "duplicate") || contains(errMsg, "already") || contains(errMsg, "nullifier")
 }
 
+// isAlreadyVoted reports whether a service refused a voter's second vote or ballot
+func isAlreadyVoted(err error) bool {
+	var alreadyVoted *voter.AlreadyVotedError
+	return errors.As(err, &alreadyVoted)
+}
+
+func containsPhaseError(errMsg string) bool {
+	return contains(errMsg, "in phase") || contains(errMsg, "phase transition")
+}
+
 func containsNotFoundError(errMsg string) bool {
 	return contains(errMsg, "not found") || contains(errMsg, "does not exist")
 }
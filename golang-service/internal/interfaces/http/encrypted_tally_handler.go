@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/ballot"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// EncryptedTallyHandler handles HTTP requests for election keys and encrypted tallies
+type EncryptedTallyHandler struct {
+	service *application.EncryptedTallyService
+}
+
+// NewEncryptedTallyHandler creates a new encrypted tally handler
+func NewEncryptedTallyHandler(service *application.EncryptedTallyService) *EncryptedTallyHandler {
+	return &EncryptedTallyHandler{service: service}
+}
+
+// CreateElectionKey handles POST /api/elections/{election_id}/key
+func (h *EncryptedTallyHandler) CreateElectionKey(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.CreateElectionKey(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetElectionKey handles GET /api/elections/{election_id}/key
+func (h *EncryptedTallyHandler) GetElectionKey(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetElectionKey(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetEncryptedTally handles GET /api/elections/{election_id}/encrypted-tally for elections decrypted by trustees
+func (h *EncryptedTallyHandler) GetEncryptedTally(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetEncryptedTally(mux.Vars(r)["election_id"], ballot.TallyRequest{})
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DecryptEncryptedTally handles POST /api/elections/{election_id}/encrypted-tally, which decrypts the
+// tally of a single-key election with the secret key in the request body
+func (h *EncryptedTallyHandler) DecryptEncryptedTally(w http.ResponseWriter, r *http.Request) {
+	var req ballot.TallyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.GetEncryptedTally(mux.Vars(r)["election_id"], req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *EncryptedTallyHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsPhaseError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case containsDuplicateError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *EncryptedTallyHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *EncryptedTallyHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
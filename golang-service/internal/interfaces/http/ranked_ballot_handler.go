@@ -44,7 +44,9 @@ func (h *RankedBallotHandler) CreateRankedBallot(w http.ResponseWriter, r *http.
 	if err != nil {
 		// Determine appropriate HTTP status code
 		statusCode := http.StatusInternalServerError
-		if containsValidationError(err.Error()) {
+		if isAlreadyVoted(err) || containsPhaseError(err.Error()) {
+			statusCode = http.StatusConflict
+		} else if containsValidationError(err.Error()) {
 			statusCode = http.StatusBadRequest
 		} else if containsDuplicateError(err.Error()) {
 			statusCode = http.StatusConflict
@@ -153,44 +155,8 @@ func (h *RankedBallotHandler) GetRankedBallotsByElection(w http.ResponseWriter,
 	json.NewEncoder(w).Encode(response)
 }
 
-// GetSchulzeResults handles GET /api/ballots/ranked/results?election_id={id}
-func (h *RankedBallotHandler) GetSchulzeResults(w http.ResponseWriter, r *http.Request) {
-	// Set response content type
-	w.Header().Set("Content-Type", "application/json")
-
-	// Only allow GET method
-	if r.Method != http.MethodGet {
-		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Extract election ID from query parameters
-	electionID := r.URL.Query().Get("election_id")
-	if electionID == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "election_id query parameter is required",
-		})
-		return
-	}
-
-	// Calculate Schulze results
-	results, err := h.service.CalculateSchulzeWinner(electionID)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": err.Error(),
-		})
-		return
-	}
-
-	// Return Schulze results
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(results)
-}
-
-// GetVoterBallots handles GET /api/ballots/ranked/voter/{voter_id}
-func (h *RankedBallotHandler) GetVoterBallots(w http.ResponseWriter, r *http.Request) {
+// GetVoterParticipation handles GET /api/ballots/ranked/voter/{voter_id}
+func (h *RankedBallotHandler) GetVoterParticipation(w http.ResponseWriter, r *http.Request) {
 	// Set response content type
 	w.Header().Set("Content-Type", "application/json")
 
@@ -220,8 +186,8 @@ func (h *RankedBallotHandler) GetVoterBallots(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Get voter ballots
-	ballots, err := h.service.GetVoterBallots(voterID)
+	// Get the elections the voter took part in
+	response, err := h.service.GetVoterParticipation(voterID)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if containsNotFoundError(err.Error()) {
@@ -235,13 +201,7 @@ func (h *RankedBallotHandler) GetVoterBallots(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Return voter ballots
-	response := map[string]interface{}{
-		"voter_id": voterID,
-		"ballots":  ballots,
-		"count":    len(ballots),
-	}
-
+	// Return voter participation
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// ReceiptHandler handles HTTP requests for the public lookup of cast receipts
+type ReceiptHandler struct {
+	service *application.ReceiptService
+}
+
+// NewReceiptHandler creates a new receipt handler
+func NewReceiptHandler(service *application.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{service: service}
+}
+
+// GetReceipt handles GET /api/receipts/{code}
+func (h *ReceiptHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetReceipt(mux.Vars(r)["code"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *ReceiptHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *ReceiptHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *ReceiptHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// ResultsHandler handles HTTP requests for election results
+type ResultsHandler struct {
+	service *application.ResultsService
+}
+
+// NewResultsHandler creates a new election results handler
+func NewResultsHandler(service *application.ResultsService) *ResultsHandler {
+	return &ResultsHandler{service: service}
+}
+
+// GetElectionResults handles GET /api/elections/{election_id}/results?method={method}.
+// Every other query parameter is passed to the method as an option.
+func (h *ResultsHandler) GetElectionResults(w http.ResponseWriter, r *http.Request) {
+	h.writeResults(w, mux.Vars(r)["election_id"], r.URL.Query())
+}
+
+// GetBallotResults handles GET /api/ballots/{ranked|approval|score}/results?election_id={id}&method={method},
+// which tabulates like GetElectionResults with the election given as a query parameter
+func (h *ResultsHandler) GetBallotResults(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	electionID := query.Get("election_id")
+	query.Del("election_id")
+	h.writeResults(w, electionID, query)
+}
+
+// writeResults tabulates an election with the method in the query, passing every other
+// query parameter to the method as an option
+func (h *ResultsHandler) writeResults(w http.ResponseWriter, electionID string, query url.Values) {
+	method := query.Get("method")
+
+	options := make(map[string]string)
+	for name := range query {
+		if name != "method" {
+			options[name] = query.Get(name)
+		}
+	}
+
+	response, err := h.service.GetElectionResults(electionID, method, options)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetTabulators handles GET /api/tabulators
+func (h *ResultsHandler) GetTabulators(w http.ResponseWriter, r *http.Request) {
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"tabulators": h.service.GetTabulators(),
+	})
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *ResultsHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsPhaseError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *ResultsHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *ResultsHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
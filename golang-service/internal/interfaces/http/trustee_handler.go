@@ -0,0 +1,193 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/application"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/trustee"
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// TrusteeHandler handles HTTP requests for trustee key generation and decryption shares
+type TrusteeHandler struct {
+	service *application.TrusteeService
+}
+
+// NewTrusteeHandler creates a new trustee handler
+func NewTrusteeHandler(service *application.TrusteeService) *TrusteeHandler {
+	return &TrusteeHandler{service: service}
+}
+
+// CreateCeremony handles POST /api/elections/{election_id}/trustees
+func (h *TrusteeHandler) CreateCeremony(w http.ResponseWriter, r *http.Request) {
+	var req trustee.CeremonyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.CreateCeremony(mux.Vars(r)["election_id"], req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetCeremony handles GET /api/elections/{election_id}/trustees
+func (h *TrusteeHandler) GetCeremony(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetCeremony(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// SubmitCommitments handles POST /api/elections/{election_id}/trustees/{index}/commitments
+func (h *TrusteeHandler) SubmitCommitments(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid trustee index")
+		return
+	}
+
+	var req trustee.CommitmentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.SubmitCommitments(mux.Vars(r)["election_id"], index, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// FileComplaint handles POST /api/elections/{election_id}/trustees/{index}/complaints
+func (h *TrusteeHandler) FileComplaint(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid trustee index")
+		return
+	}
+
+	var req trustee.ComplaintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.FileComplaint(mux.Vars(r)["election_id"], index, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// RevealShare handles POST /api/elections/{election_id}/trustees/{index}/revealed-shares
+func (h *TrusteeHandler) RevealShare(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid trustee index")
+		return
+	}
+
+	var req trustee.RevealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.RevealShare(mux.Vars(r)["election_id"], index, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// FinalizeCeremony handles POST /api/elections/{election_id}/trustees/key
+func (h *TrusteeHandler) FinalizeCeremony(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.FinalizeCeremony(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetAggregate handles GET /api/elections/{election_id}/encrypted-aggregate
+func (h *TrusteeHandler) GetAggregate(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetAggregate(mux.Vars(r)["election_id"])
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// SubmitDecryptionShare handles POST /api/elections/{election_id}/trustees/{index}/decryption-shares
+func (h *TrusteeHandler) SubmitDecryptionShare(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid trustee index")
+		return
+	}
+
+	var req trustee.DecryptionShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.SubmitDecryptionShare(mux.Vars(r)["election_id"], index, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *TrusteeHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsPhaseError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case containsDuplicateError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *TrusteeHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *TrusteeHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
@@ -54,7 +54,7 @@ func (h *VoteHandler) CastWeightedVote(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.CastWeightedVote(req)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("voter with id: %d has already voted", req.VoterID) {
+		if isAlreadyVoted(err) {
 			h.writeErrorResponse(w, http.StatusConflict, err.Error())
 			return
 		}
@@ -62,6 +62,10 @@ func (h *VoteHandler) CastWeightedVote(w http.ResponseWriter, r *http.Request) {
 			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if err.Error() == fmt.Sprintf("candidate with id: %d was not found", req.CandidateID) {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
@@ -103,6 +103,10 @@ func (h *VoterHandler) UpdateVoter(w http.ResponseWriter, r *http.Request) {
 			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if containsPhaseError(err.Error()) {
+			h.writeErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
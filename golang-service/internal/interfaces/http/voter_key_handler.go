@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Nezent/Saracen_Voting_System/internal/domain/voter"
+	"github.com/gorilla/mux"
+)
+
+// VoterKeyHandler handles HTTP requests for voter key enrollment
+type VoterKeyHandler struct {
+	service voter.KeyService
+}
+
+// NewVoterKeyHandler creates a new voter key HTTP handler
+func NewVoterKeyHandler(service voter.KeyService) *VoterKeyHandler {
+	return &VoterKeyHandler{service: service}
+}
+
+// EnrollKey handles POST /api/voters/{voter_id}/keys
+func (h *VoterKeyHandler) EnrollKey(w http.ResponseWriter, r *http.Request) {
+	voterID, err := strconv.Atoi(mux.Vars(r)["voter_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid voter ID")
+		return
+	}
+
+	var req voter.KeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.EnrollKey(voterID, req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetVoterKeys handles GET /api/voters/{voter_id}/keys?election_id={election_id}
+func (h *VoterKeyHandler) GetVoterKeys(w http.ResponseWriter, r *http.Request) {
+	voterID, err := strconv.Atoi(mux.Vars(r)["voter_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid voter ID")
+		return
+	}
+
+	response, err := h.service.GetVoterKeys(voterID, r.URL.Query().Get("election_id"))
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// RevokeKey handles DELETE /api/voters/{voter_id}/keys/{key_id}
+func (h *VoterKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	voterID, err := strconv.Atoi(vars["voter_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid voter ID")
+		return
+	}
+	keyID, err := strconv.Atoi(vars["key_id"])
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid key ID")
+		return
+	}
+
+	response, err := h.service.RevokeKey(voterID, keyID)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func (h *VoterKeyHandler) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case containsNotFoundError(err.Error()):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case containsPhaseError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case containsValidationError(err.Error()):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case containsDuplicateError(err.Error()):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *VoterKeyHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeErrorResponse writes an error response
+func (h *VoterKeyHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	errorResponse := voter.ErrorResponse{Message: message}
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
@@ -0,0 +1,36 @@
+-- Migration: Add elections and election_candidates tables
+-- Created: 2026-10-16 09:00:00
+
+-- CreateTable: Elections
+CREATE TABLE "public"."elections" (
+    "election_id" TEXT NOT NULL,
+    "title" TEXT NOT NULL,
+    "ballot_type" TEXT NOT NULL,
+    "opens_at" TIMESTAMP(3) NOT NULL,
+    "closes_at" TIMESTAMP(3) NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'draft',
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "elections_pkey" PRIMARY KEY ("election_id")
+);
+
+-- CreateTable: Election candidate slate
+CREATE TABLE "public"."election_candidates" (
+    "election_id" TEXT NOT NULL,
+    "candidate_id" INTEGER NOT NULL,
+    "ballot_position" INTEGER NOT NULL,
+
+    CONSTRAINT "election_candidates_pkey" PRIMARY KEY ("election_id", "candidate_id")
+);
+
+-- CreateIndex: Optimize queries by status
+CREATE INDEX "elections_status_idx" ON "public"."elections"("status");
+
+-- AddForeignKey: Link slate entries to elections
+ALTER TABLE "public"."election_candidates" ADD CONSTRAINT "election_candidates_election_id_fkey" 
+FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey: Link slate entries to candidates
+ALTER TABLE "public"."election_candidates" ADD CONSTRAINT "election_candidates_candidate_id_fkey" 
+FOREIGN KEY ("candidate_id") REFERENCES "public"."candidate"("candidate_id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,30 @@
+-- Migration: Replace election status with lifecycle phases and record phase transitions
+-- Created: 2026-10-16 10:00:00
+
+-- RenameColumn: status becomes phase
+ALTER TABLE "public"."elections" RENAME COLUMN "status" TO "phase";
+
+-- Map the old open status onto the voting phase
+UPDATE "public"."elections" SET "phase" = 'voting' WHERE "phase" = 'open';
+
+-- RenameIndex
+ALTER INDEX "public"."elections_status_idx" RENAME TO "elections_phase_idx";
+
+-- CreateTable: Election phase transitions
+CREATE TABLE "public"."election_transitions" (
+    "id" SERIAL NOT NULL,
+    "election_id" TEXT NOT NULL,
+    "from_phase" TEXT NOT NULL,
+    "to_phase" TEXT NOT NULL,
+    "actor" TEXT NOT NULL,
+    "transitioned_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "election_transitions_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex: Optimize queries by election_id
+CREATE INDEX "election_transitions_election_id_idx" ON "public"."election_transitions"("election_id");
+
+-- AddForeignKey: Link transitions to elections
+ALTER TABLE "public"."election_transitions" ADD CONSTRAINT "election_transitions_election_id_fkey" 
+FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,7 @@
+-- Migration: Allow equal rankings within a ranked ballot
+-- Created: 2026-10-16 11:00:00
+
+-- rank_position may repeat within a ballot for candidates ranked equally,
+-- but each candidate may still appear only once per ballot
+CREATE UNIQUE INDEX "ballot_rankings_ballot_id_candidate_id_key" ON "public"."ballot_rankings"("ballot_id", "candidate_id");
+
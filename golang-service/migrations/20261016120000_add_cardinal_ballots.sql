@@ -0,0 +1,49 @@
+-- Migration: Add approval and score (cardinal) ballots
+-- Created: 2026-10-16 12:00:00
+
+-- AddColumn: Highest score a score ballot may give a candidate (0 for other ballot types)
+ALTER TABLE "public"."elections" ADD COLUMN "max_score" INTEGER NOT NULL DEFAULT 0;
+
+-- CreateTable: Cardinal Ballots (approval and score)
+CREATE TABLE "public"."cardinal_ballots" (
+    "ballot_id" TEXT NOT NULL,
+    "election_id" TEXT NOT NULL,
+    "voter_id" INTEGER NOT NULL,
+    "ballot_type" TEXT NOT NULL,
+    "timestamp" TIMESTAMP(3) NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'accepted',
+
+    CONSTRAINT "cardinal_ballots_pkey" PRIMARY KEY ("ballot_id")
+);
+
+-- CreateTable: Ballot Scores (an approval is stored as a score of 1)
+CREATE TABLE "public"."ballot_scores" (
+    "id" SERIAL NOT NULL,
+    "ballot_id" TEXT NOT NULL,
+    "candidate_id" INTEGER NOT NULL,
+    "score" INTEGER NOT NULL,
+
+    CONSTRAINT "ballot_scores_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ballot_scores_score_check" CHECK ("score" >= 0)
+);
+
+-- CreateIndex: Optimize queries by election and ballot type
+CREATE INDEX "cardinal_ballots_election_id_ballot_type_idx" ON "public"."cardinal_ballots"("election_id", "ballot_type");
+
+-- CreateIndex: Optimize queries by voter
+CREATE INDEX "cardinal_ballots_voter_id_idx" ON "public"."cardinal_ballots"("voter_id");
+
+-- CreateIndex: Each candidate is scored at most once per ballot
+CREATE UNIQUE INDEX "ballot_scores_ballot_id_candidate_id_key" ON "public"."ballot_scores"("ballot_id", "candidate_id");
+
+-- AddForeignKey: Link cardinal ballots to voters
+ALTER TABLE "public"."cardinal_ballots" ADD CONSTRAINT "cardinal_ballots_voter_id_fkey"
+FOREIGN KEY ("voter_id") REFERENCES "public"."voter"("voter_id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey: Link ballot scores to cardinal ballots
+ALTER TABLE "public"."ballot_scores" ADD CONSTRAINT "ballot_scores_ballot_id_fkey"
+FOREIGN KEY ("ballot_id") REFERENCES "public"."cardinal_ballots"("ballot_id") ON DELETE CASCADE;
+
+-- AddForeignKey: Link ballot scores to candidates
+ALTER TABLE "public"."ballot_scores" ADD CONSTRAINT "ballot_scores_candidate_id_fkey"
+FOREIGN KEY ("candidate_id") REFERENCES "public"."candidate"("candidate_id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,18 @@
+-- Migration: Add the official counting method of each election
+-- Created: 2026-10-16 13:00:00
+
+-- AddColumn: Official counting method (empty for encrypted elections, which are not tabulated)
+ALTER TABLE "public"."elections" ADD COLUMN "method" TEXT NOT NULL DEFAULT '';
+
+-- AddColumn: Options of the official counting method, with every option resolved to a value
+ALTER TABLE "public"."elections" ADD COLUMN "method_options" JSONB NOT NULL DEFAULT '{}';
+
+-- Backfill: Existing elections count with the default method of their ballot type and its default options
+UPDATE "public"."elections"
+SET "method" = 'schulze',
+    "method_options" = '{"strength": "winning_votes", "unranked": "below_ranked", "equal_ranking": "ignore"}'
+WHERE "ballot_type" = 'ranked';
+
+UPDATE "public"."elections" SET "method" = 'approval' WHERE "ballot_type" = 'approval';
+
+UPDATE "public"."elections" SET "method" = 'score' WHERE "ballot_type" = 'score';
@@ -0,0 +1,41 @@
+-- Migration: Add incrementally maintained pairwise tallies of ranked ballots
+-- Created: 2026-10-16 14:00:00
+
+-- CreateTable: Candidate Tallies (ballots ranking each candidate, per election)
+CREATE TABLE "public"."candidate_tallies" (
+    "election_id" TEXT NOT NULL,
+    "candidate_id" INTEGER NOT NULL,
+    "ranked" INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT "candidate_tallies_pkey" PRIMARY KEY ("election_id", "candidate_id")
+);
+
+-- CreateTable: Pairwise Tallies (ballots ranking both candidates of a pair, with candidate_a < candidate_b)
+CREATE TABLE "public"."pairwise_tallies" (
+    "election_id" TEXT NOT NULL,
+    "candidate_a" INTEGER NOT NULL,
+    "candidate_b" INTEGER NOT NULL,
+    "a_over_b" INTEGER NOT NULL DEFAULT 0,
+    "b_over_a" INTEGER NOT NULL DEFAULT 0,
+    "equal" INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT "pairwise_tallies_pkey" PRIMARY KEY ("election_id", "candidate_a", "candidate_b"),
+    CONSTRAINT "pairwise_tallies_order_check" CHECK ("candidate_a" < "candidate_b")
+);
+
+-- Backfill: Count the ranked ballots cast before tallies were maintained
+INSERT INTO "public"."candidate_tallies" ("election_id", "candidate_id", "ranked")
+SELECT rb."election_id", br."candidate_id", COUNT(*)
+FROM "public"."ranked_ballots" rb
+JOIN "public"."ballot_rankings" br ON br."ballot_id" = rb."ballot_id"
+GROUP BY rb."election_id", br."candidate_id";
+
+INSERT INTO "public"."pairwise_tallies" ("election_id", "candidate_a", "candidate_b", "a_over_b", "b_over_a", "equal")
+SELECT rb."election_id", a."candidate_id", b."candidate_id",
+       COUNT(*) FILTER (WHERE a."rank_position" < b."rank_position"),
+       COUNT(*) FILTER (WHERE a."rank_position" > b."rank_position"),
+       COUNT(*) FILTER (WHERE a."rank_position" = b."rank_position")
+FROM "public"."ranked_ballots" rb
+JOIN "public"."ballot_rankings" a ON a."ballot_id" = rb."ballot_id"
+JOIN "public"."ballot_rankings" b ON b."ballot_id" = rb."ballot_id" AND a."candidate_id" < b."candidate_id"
+GROUP BY rb."election_id", a."candidate_id", b."candidate_id";
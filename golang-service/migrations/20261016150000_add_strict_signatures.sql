@@ -0,0 +1,5 @@
+-- Migration: Add strict signature mode for encrypted ballot elections
+-- Created: 2026-10-16 15:00:00
+
+-- AddColumn: Require a verified Ed25519 signature on every encrypted ballot (false keeps lenient validation)
+ALTER TABLE "public"."elections" ADD COLUMN "strict_signatures" BOOLEAN NOT NULL DEFAULT false;
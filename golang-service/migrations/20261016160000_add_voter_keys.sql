@@ -0,0 +1,27 @@
+-- Migration: Add voter key enrollment for encrypted ballots
+-- Created: 2026-10-16 16:00:00
+
+-- CreateTable: Ed25519 public keys voters enrolled per election
+CREATE TABLE "public"."voter_keys" (
+    "key_id" SERIAL NOT NULL,
+    "voter_id" INTEGER NOT NULL,
+    "election_id" TEXT NOT NULL,
+    "public_key" TEXT NOT NULL,
+    "enrolled_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "revoked_at" TIMESTAMP(3),
+
+    CONSTRAINT "voter_keys_pkey" PRIMARY KEY ("key_id"),
+    -- A public key identifies one voter in an election, and is never enrolled again once revoked
+    CONSTRAINT "voter_keys_election_id_public_key_key" UNIQUE ("election_id", "public_key")
+);
+
+-- CreateIndex: Optimize queries by voter
+CREATE INDEX "voter_keys_voter_id_election_id_idx" ON "public"."voter_keys"("voter_id", "election_id");
+
+-- AddForeignKey: Link keys to voters
+ALTER TABLE "public"."voter_keys" ADD CONSTRAINT "voter_keys_voter_id_fkey"
+FOREIGN KEY ("voter_id") REFERENCES "public"."voter"("voter_id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey: Link keys to elections
+ALTER TABLE "public"."voter_keys" ADD CONSTRAINT "voter_keys_election_id_fkey"
+FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
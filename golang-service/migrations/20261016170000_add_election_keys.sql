@@ -0,0 +1,16 @@
+-- Migration: Add ElGamal key pairs for encrypted ballot elections
+-- Created: 2026-10-16 17:00:00
+
+-- CreateTable: Election key pairs (hex encoded, RFC 3526 2048-bit MODP group)
+CREATE TABLE "public"."election_keys" (
+    "election_id" TEXT NOT NULL,
+    "public_key" TEXT NOT NULL,
+    "secret_key" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "election_keys_pkey" PRIMARY KEY ("election_id")
+);
+
+-- AddForeignKey: Link keys to elections
+ALTER TABLE "public"."election_keys" ADD CONSTRAINT "election_keys_election_id_fkey"
+FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
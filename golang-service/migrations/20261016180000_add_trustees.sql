@@ -0,0 +1,72 @@
+-- Migration: Add threshold key generation and decryption by election trustees
+-- Created: 2026-10-16 18:00:00
+
+-- AlterColumn: Keys generated by trustees have no secret key
+ALTER TABLE "public"."election_keys" ALTER COLUMN "secret_key" DROP NOT NULL;
+
+-- AddColumn: Number of trustees needed to decrypt (0 for a key with a secret key)
+ALTER TABLE "public"."election_keys" ADD COLUMN "threshold" INTEGER NOT NULL DEFAULT 0;
+
+-- CreateTable: Key generation ceremonies
+CREATE TABLE "public"."trustee_ceremonies" (
+    "election_id" TEXT NOT NULL,
+    "threshold" INTEGER NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "finalized_at" TIMESTAMP(3),
+
+    CONSTRAINT "trustee_ceremonies_pkey" PRIMARY KEY ("election_id")
+);
+
+-- CreateTable: Trustees and their Feldman commitments (hex group elements)
+CREATE TABLE "public"."trustees" (
+    "election_id" TEXT NOT NULL,
+    "trustee_index" INTEGER NOT NULL,
+    "name" TEXT NOT NULL,
+    "commitments" TEXT[] NOT NULL DEFAULT '{}',
+    "committed_at" TIMESTAMP(3),
+    "disqualified" BOOLEAN NOT NULL DEFAULT false,
+    "verification_key" TEXT NOT NULL DEFAULT '',
+
+    CONSTRAINT "trustees_pkey" PRIMARY KEY ("election_id", "trustee_index")
+);
+
+-- CreateTable: Complaints against dealers and the shares revealed to answer them
+CREATE TABLE "public"."trustee_complaints" (
+    "election_id" TEXT NOT NULL,
+    "dealer_index" INTEGER NOT NULL,
+    "complainer_index" INTEGER NOT NULL,
+    "revealed_share" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "resolved_at" TIMESTAMP(3),
+
+    CONSTRAINT "trustee_complaints_pkey" PRIMARY KEY ("election_id", "dealer_index", "complainer_index")
+);
+
+-- CreateTable: Partial decryptions of the aggregate ciphertext with Chaum-Pedersen proofs
+CREATE TABLE "public"."decryption_shares" (
+    "election_id" TEXT NOT NULL,
+    "trustee_index" INTEGER NOT NULL,
+    "factors" TEXT[] NOT NULL,
+    "proofs" JSONB NOT NULL,
+    "submitted_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "decryption_shares_pkey" PRIMARY KEY ("election_id", "trustee_index")
+);
+
+-- AddForeignKey: Link ceremonies to elections
+ALTER TABLE "public"."trustee_ceremonies" ADD CONSTRAINT "trustee_ceremonies_election_id_fkey"
+FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey: Link trustees to ceremonies
+ALTER TABLE "public"."trustees" ADD CONSTRAINT "trustees_election_id_fkey"
+FOREIGN KEY ("election_id") REFERENCES "public"."trustee_ceremonies"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey: Link complaints to the dealer and the complainer
+ALTER TABLE "public"."trustee_complaints" ADD CONSTRAINT "trustee_complaints_dealer_fkey"
+FOREIGN KEY ("election_id", "dealer_index") REFERENCES "public"."trustees"("election_id", "trustee_index") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "public"."trustee_complaints" ADD CONSTRAINT "trustee_complaints_complainer_fkey"
+FOREIGN KEY ("election_id", "complainer_index") REFERENCES "public"."trustees"("election_id", "trustee_index") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey: Link decryption shares to trustees
+ALTER TABLE "public"."decryption_shares" ADD CONSTRAINT "decryption_shares_trustee_fkey"
+FOREIGN KEY ("election_id", "trustee_index") REFERENCES "public"."trustees"("election_id", "trustee_index") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,8 @@
+-- Migration: Add the allowed number of selections on encrypted ballots
+-- Created: 2026-10-16 19:00:00
+
+-- AddColumn: Most candidates an encrypted ballot may select (0 for other ballot types)
+ALTER TABLE "public"."elections" ADD COLUMN "max_selections" INTEGER NOT NULL DEFAULT 0;
+
+-- Existing encrypted elections select a single candidate
+UPDATE "public"."elections" SET "max_selections" = 1 WHERE "ballot_type" = 'encrypted';
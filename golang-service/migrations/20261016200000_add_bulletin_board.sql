@@ -0,0 +1,77 @@
+-- Migration: Add the hash-chained bulletin board of accepted encrypted ballots and its signed checkpoints
+-- Created: 2026-10-16 20:00:00
+
+-- CreateTable: Board Entries (one per accepted encrypted ballot, numbered from 1 per election)
+CREATE TABLE "public"."board_entries" (
+    "election_id" TEXT NOT NULL,
+    "sequence" BIGINT NOT NULL,
+    "ballot_id" TEXT NOT NULL,
+    "ballot_hash" TEXT NOT NULL,
+    "prev_hash" TEXT NOT NULL,
+    "hash" TEXT NOT NULL,
+    "appended_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "board_entries_pkey" PRIMARY KEY ("election_id", "sequence"),
+    CONSTRAINT "board_entries_ballot_id_key" UNIQUE ("ballot_id")
+);
+
+-- CreateTable: Board Checkpoints (signed size and head hash of a board)
+CREATE TABLE "public"."board_checkpoints" (
+    "election_id" TEXT NOT NULL,
+    "size" BIGINT NOT NULL,
+    "head_hash" TEXT NOT NULL,
+    "public_key" TEXT NOT NULL,
+    "signature" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "board_checkpoints_pkey" PRIMARY KEY ("election_id", "size")
+);
+
+-- AddForeignKey: Board entries can never outlive their ballots
+ALTER TABLE "public"."board_entries" ADD CONSTRAINT "board_entries_ballot_id_fkey"
+    FOREIGN KEY ("ballot_id") REFERENCES "public"."encrypted_ballots"("ballot_id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- Backfill: Chain the ballots accepted before the board existed, in the order they were anchored.
+-- board_field prefixes a field with its length as a big-endian uint32, as the service does.
+CREATE FUNCTION pg_temp.board_field(value BYTEA) RETURNS BYTEA AS $$
+    SELECT int4send(octet_length(value)) || value
+$$ LANGUAGE SQL IMMUTABLE;
+
+WITH RECURSIVE "ordered" AS (
+    SELECT "election_id", "ballot_id", "anchored_at",
+           ROW_NUMBER() OVER (PARTITION BY "election_id" ORDER BY "anchored_at", "ballot_id") AS "sequence",
+           sha256(convert_to('saracen-voting/board-ballot/v1', 'UTF8')
+               || pg_temp.board_field(convert_to("election_id", 'UTF8'))
+               || pg_temp.board_field(convert_to("ballot_id", 'UTF8'))
+               || pg_temp.board_field(convert_to("ciphertext", 'UTF8'))
+               || pg_temp.board_field(convert_to("zk_proof", 'UTF8'))
+               || pg_temp.board_field(convert_to("voter_pubkey", 'UTF8'))
+               || pg_temp.board_field(convert_to("nullifier", 'UTF8'))
+               || pg_temp.board_field(convert_to("signature", 'UTF8'))) AS "ballot_hash"
+    FROM "public"."encrypted_ballots"
+    WHERE "status" = 'accepted'
+), "chain" AS (
+    SELECT o."election_id", o."sequence", o."ballot_id", o."ballot_hash", o."anchored_at",
+           sha256(convert_to('saracen-voting/board-entry/v1', 'UTF8')
+               || pg_temp.board_field(convert_to(o."election_id", 'UTF8'))) AS "prev_hash"
+    FROM "ordered" o
+    WHERE o."sequence" = 1
+    UNION ALL
+    SELECT o."election_id", o."sequence", o."ballot_id", o."ballot_hash", o."anchored_at",
+           sha256(convert_to('saracen-voting/board-entry/v1', 'UTF8')
+               || pg_temp.board_field(convert_to(c."election_id", 'UTF8'))
+               || pg_temp.board_field(c."prev_hash")
+               || pg_temp.board_field(convert_to(c."sequence"::TEXT, 'UTF8'))
+               || pg_temp.board_field(c."ballot_hash"))
+    FROM "chain" c
+    JOIN "ordered" o ON o."election_id" = c."election_id" AND o."sequence" = c."sequence" + 1
+)
+INSERT INTO "public"."board_entries" ("election_id", "sequence", "ballot_id", "ballot_hash", "prev_hash", "hash", "appended_at")
+SELECT c."election_id", c."sequence", c."ballot_id", encode(c."ballot_hash", 'hex'), encode(c."prev_hash", 'hex'),
+       encode(sha256(convert_to('saracen-voting/board-entry/v1', 'UTF8')
+           || pg_temp.board_field(convert_to(c."election_id", 'UTF8'))
+           || pg_temp.board_field(c."prev_hash")
+           || pg_temp.board_field(convert_to(c."sequence"::TEXT, 'UTF8'))
+           || pg_temp.board_field(c."ballot_hash")), 'hex'),
+       c."anchored_at"
+FROM "chain" c;
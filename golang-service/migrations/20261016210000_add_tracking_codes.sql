@@ -0,0 +1,46 @@
+-- Migration: Add tracking codes to accepted encrypted and ranked ballots for voter receipts
+-- Created: 2026-10-16 21:00:00
+
+-- AlterTable: Tracking codes (null for ballots that are not accepted)
+ALTER TABLE "public"."encrypted_ballots" ADD COLUMN "tracking_code" TEXT;
+ALTER TABLE "public"."ranked_ballots" ADD COLUMN "tracking_code" TEXT;
+
+-- Backfill: Derive the codes of the ballots accepted before receipts existed, as the service does.
+-- tracking_code writes the first 80 bits of a hash as 16 base32 characters in groups of 4;
+-- receipt_field prefixes a field with its length as a big-endian uint32.
+CREATE FUNCTION pg_temp.tracking_code(hash BYTEA) RETURNS TEXT AS $$
+    SELECT substr(code, 1, 4) || '-' || substr(code, 5, 4) || '-' || substr(code, 9, 4) || '-' || substr(code, 13, 4)
+    FROM (
+        SELECT string_agg(substr('ABCDEFGHIJKLMNOPQRSTUVWXYZ234567',
+                   substring(('x' || encode(substring(hash FROM 1 FOR 10), 'hex'))::BIT(80) FROM i * 5 + 1 FOR 5)::INTEGER + 1, 1),
+                   '' ORDER BY i) AS code
+        FROM generate_series(0, 15) AS i
+    ) AS encoded
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE FUNCTION pg_temp.receipt_field(value BYTEA) RETURNS BYTEA AS $$
+    SELECT int4send(octet_length(value)) || value
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Encrypted ballots take the code of the ballot hash of their bulletin board entry
+UPDATE "public"."encrypted_ballots" eb
+SET "tracking_code" = pg_temp.tracking_code(decode(be."ballot_hash", 'hex'))
+FROM "public"."board_entries" be
+WHERE be."ballot_id" = eb."ballot_id" AND eb."status" = 'accepted';
+
+-- Ranked ballots hash their election, ID and rankings ordered by rank position then candidate
+UPDATE "public"."ranked_ballots" rb
+SET "tracking_code" = pg_temp.tracking_code(sha256(convert_to('saracen-voting/ranked-ballot/v1', 'UTF8')
+    || pg_temp.receipt_field(convert_to(rb."election_id", 'UTF8'))
+    || pg_temp.receipt_field(convert_to(rb."ballot_id", 'UTF8'))
+    || COALESCE((
+        SELECT string_agg(pg_temp.receipt_field(convert_to(br."rank_position" || ':' || br."candidate_id", 'UTF8')), ''::BYTEA
+                          ORDER BY br."rank_position", br."candidate_id")
+        FROM "public"."ballot_rankings" br
+        WHERE br."ballot_id" = rb."ballot_id"
+    ), ''::BYTEA)))
+WHERE rb."status" = 'accepted';
+
+-- CreateIndex: Tracking codes identify one ballot across receipts
+CREATE UNIQUE INDEX "encrypted_ballots_tracking_code_key" ON "public"."encrypted_ballots"("tracking_code");
+CREATE UNIQUE INDEX "ranked_ballots_tracking_code_key" ON "public"."ranked_ballots"("tracking_code");
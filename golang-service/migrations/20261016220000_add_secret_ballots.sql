@@ -0,0 +1,27 @@
+-- Migration: Add secret-ballot ranked elections, whose ballots are stored apart from their voters
+-- Created: 2026-10-16 22:00:00
+
+-- AlterTable: Elections whose ranked ballots carry no voter reference
+ALTER TABLE "public"."elections" ADD COLUMN "secret_ballot" BOOLEAN NOT NULL DEFAULT false;
+
+-- AlterTable: Secret ballots are stored without their voter or timestamp
+ALTER TABLE "public"."ranked_ballots" ALTER COLUMN "voter_id" DROP NOT NULL;
+ALTER TABLE "public"."ranked_ballots" ALTER COLUMN "timestamp" DROP NOT NULL;
+
+-- CreateTable: Election Participation (which voters cast a ballot in a secret-ballot election, but not
+-- which ballot or when, so it has no timestamp or serial key)
+CREATE TABLE "public"."election_participation" (
+    "election_id" TEXT NOT NULL,
+    "voter_id" INTEGER NOT NULL,
+
+    CONSTRAINT "election_participation_pkey" PRIMARY KEY ("election_id", "voter_id")
+);
+
+-- CreateIndex: Look up a voter's participation
+CREATE INDEX "election_participation_voter_id_idx" ON "public"."election_participation"("voter_id");
+
+-- AddForeignKey: Link participation to elections and voters
+ALTER TABLE "public"."election_participation" ADD CONSTRAINT "election_participation_election_id_fkey"
+    FOREIGN KEY ("election_id") REFERENCES "public"."elections"("election_id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "public"."election_participation" ADD CONSTRAINT "election_participation_voter_id_fkey"
+    FOREIGN KEY ("voter_id") REFERENCES "public"."voter"("voter_id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,6 @@
+-- Migration: Add per-election revoting policies, under which a voter's new ballot supersedes the earlier one
+-- Created: 2026-10-16 23:00:00
+
+-- AlterTable: What happens to a voter's second ballot ('reject' or 'last_ballot'). Superseded ballots
+-- keep their row with the status 'superseded' and are left out of every tally.
+ALTER TABLE "public"."elections" ADD COLUMN "revote_policy" TEXT NOT NULL DEFAULT 'reject';
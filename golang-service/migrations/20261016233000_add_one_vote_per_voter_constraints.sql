@@ -0,0 +1,21 @@
+-- Migration: Enforce one vote per voter in the database, so concurrent requests cannot both pass the services' checks
+-- Created: 2026-10-16 23:30:00
+
+-- Creating these indexes fails if the races they close already let a voter vote twice; such votes
+-- and ballots have to be resolved by hand before the migration is rerun.
+
+-- CreateIndex: A voter casts one weighted vote
+CREATE UNIQUE INDEX "votes_voter_id_key" ON "public"."votes"("voter_id");
+
+-- CreateIndex: A voter has one accepted ballot per election. Superseded ballots are left out so
+-- revoting elections can keep them, and secret ballots, which have no voter, are unique through
+-- election_participation instead.
+CREATE UNIQUE INDEX "ranked_ballots_election_id_voter_id_key" ON "public"."ranked_ballots"("election_id", "voter_id")
+    WHERE "status" = 'accepted' AND "voter_id" IS NOT NULL;
+CREATE UNIQUE INDEX "cardinal_ballots_election_id_voter_id_key" ON "public"."cardinal_ballots"("election_id", "voter_id")
+    WHERE "status" = 'accepted';
+
+-- CreateIndex: A voter has one accepted encrypted ballot per election. The nullifier is chosen by the
+-- client, so its own unique index does not stop a voter from casting a second ballot under a fresh one.
+CREATE UNIQUE INDEX "encrypted_ballots_election_id_voter_id_key" ON "public"."encrypted_ballots"("election_id", "voter_id")
+    WHERE "status" = 'accepted';
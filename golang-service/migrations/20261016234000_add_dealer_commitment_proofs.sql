@@ -0,0 +1,6 @@
+-- Migration: Store each dealer's proof of knowledge of the constant term of its commitments
+-- Created: 2026-10-16 23:40:00
+
+-- AlterTable: The Schnorr proof a dealer publishes with its commitments. Dealers that committed before
+-- proofs were required have none, and no longer qualify when their ceremony is finalized.
+ALTER TABLE "public"."trustees" ADD COLUMN "commitment_proof" JSONB;
@@ -0,0 +1,10 @@
+-- Migration: Stop storing the secret keys of single-key elections next to their ballots
+-- Created: 2026-10-16 23:50:00
+
+-- The secret key is returned once when the key is generated, and the operator supplies it to decrypt
+-- the tally. Dropping the column deletes the keys already stored: export them first, with
+-- SELECT "election_id", "secret_key" FROM "public"."election_keys" WHERE "secret_key" IS NOT NULL,
+-- to tally those elections.
+
+-- AlterTable: Only the public key is kept
+ALTER TABLE "public"."election_keys" DROP COLUMN "secret_key";